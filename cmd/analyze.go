@@ -0,0 +1,256 @@
+package main
+
+import "fmt"
+
+// Outcome describes how an analyzed pattern ended up.
+type Outcome int
+
+const (
+	// Capped means the generation cap was reached before the pattern settled.
+	Capped Outcome = iota
+
+	// Extinct means every cell died.
+	Extinct
+
+	// StillLife means a generation was identical to the one before it.
+	StillLife
+
+	// Oscillating means a generation repeated an earlier one with a period above 1.
+	Oscillating
+
+	// Spaceship means the live cells repeated an earlier shape at a different position.
+	Spaceship
+)
+
+// String returns a lower-case description of the outcome.
+func (o Outcome) String() string {
+	switch o {
+	case Capped:
+		return "capped"
+	case Extinct:
+		return "extinct"
+	case StillLife:
+		return "still life"
+	case Oscillating:
+		return "oscillating"
+	case Spaceship:
+		return "spaceship"
+	default:
+		return fmt.Sprintf("Outcome(%d)", int(o))
+	}
+}
+
+// Analysis summarizes the result of Game.Analyze.
+type Analysis struct {
+	// Outcome is the condition that ended the analysis.
+	Outcome Outcome
+
+	// Generation is the generation at which the outcome was detected.
+	Generation int
+
+	// Start is the first generation of the final state: the generation the repeating
+	// state first appeared at for StillLife and Oscillating, otherwise Generation.
+	Start int
+
+	// Period is the length of the cycle, 1 for StillLife and 0 when there is no cycle.
+	Period int
+
+	// DX and DY are the rows and columns a Spaceship moves every Period generations.
+	DX, DY int
+
+	// Population is the live-cell count at Generation.
+	Population int
+}
+
+// String formats the analysis as a one-line report.
+func (a Analysis) String() string {
+	switch a.Outcome {
+	case Extinct:
+		return fmt.Sprintf("Extinct at generation %d", a.Generation)
+	case StillLife:
+		return fmt.Sprintf("Still life from generation %d with %d live cells", a.Start, a.Population)
+	case Oscillating:
+		return fmt.Sprintf("Oscillating with period %d from generation %d (detected at generation %d)",
+			a.Period, a.Start, a.Generation)
+	case Spaceship:
+		return fmt.Sprintf("Spaceship moving (%d, %d) every %d generations from generation %d: %s",
+			a.DX, a.DY, a.Period, a.Start, a.Speed())
+	default:
+		return fmt.Sprintf("No fixed point within %d generations, %d live cells", a.Generation, a.Population)
+	}
+}
+
+// Speed describes the velocity of a Spaceship in the usual notation, e.g. "c/4 diagonal"
+// for a glider or "c/2 orthogonal" for a lightweight spaceship, where c is one cell per
+// generation. It returns "" for any other outcome.
+func (a Analysis) Speed() string {
+	if a.Outcome != Spaceship {
+		return ""
+	}
+
+	dx, dy := abs(a.DX), abs(a.DY)
+	cells := max(dx, dy)
+	direction := "oblique"
+	switch {
+	case dx == 0 || dy == 0:
+		direction = "orthogonal"
+	case dx == dy:
+		direction = "diagonal"
+	}
+
+	d := gcd(cells, a.Period)
+	if cells/d == 1 {
+		return fmt.Sprintf("c/%d %s", a.Period/d, direction)
+	}
+	return fmt.Sprintf("%dc/%d %s", cells/d, a.Period/d, direction)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// gcd returns the greatest common divisor of two non-negative integers.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Analyze advances the game until it dies out, stops changing, repeats an earlier
+// generation or repeats an earlier shape elsewhere on the grid, or until maxGenerations
+// generations have been simulated, whichever comes first; a maxGenerations of 0 sets no
+// limit. Repeats are found by hashing the shape of each generation relative to its
+// bounding box, so cycles longer than history generations are not recognized.
+//
+// Parameters:
+//   - maxGenerations: The maximum number of generations to simulate, 0 for no limit.
+//   - history: The number of past generations remembered for cycle detection.
+//
+// Returns:
+//   - An Analysis describing the outcome.
+func (g *Game) Analyze(maxGenerations, history int) Analysis {
+	cycles := newCycleDetector(history)
+	cycles.observeAt(g.ShapeHash(), g.gen, g.origin())
+
+	for i := 0; maxGenerations == 0 || i < maxGenerations; i++ {
+		g.NextGen()
+
+		population := g.CountLiveCells()
+		if population == 0 {
+			return Analysis{Outcome: Extinct, Generation: g.gen, Start: g.gen}
+		}
+		period, offset, ok := cycles.observeAt(g.ShapeHash(), g.gen, g.origin())
+		if !ok {
+			continue
+		}
+
+		a := Analysis{Generation: g.gen, Start: g.gen - period, Period: period, Population: population}
+		a.DX = wrapOffset(offset[0], g.grid.Height(), g.boundaryX)
+		a.DY = wrapOffset(offset[1], g.grid.Width(), g.boundaryY)
+		switch {
+		case a.DX != 0 || a.DY != 0:
+			a.Outcome = Spaceship
+		case period == 1:
+			a.Outcome = StillLife
+		default:
+			a.Outcome = Oscillating
+		}
+		return a
+	}
+
+	return Analysis{Outcome: Capped, Generation: g.gen, Start: g.gen, Population: g.CountLiveCells()}
+}
+
+// origin returns the top-left corner of the live cells' bounding box, or 0, 0 for an
+// empty grid.
+func (g *Game) origin() [2]int {
+	minX, minY, _, _, _ := g.BoundingBox()
+	return [2]int{minX, minY}
+}
+
+// wrapOffset maps a displacement along an axis of length n to the shortest equivalent
+// one when the axis wraps, so a pattern that crossed the edge is not reported as moving
+// almost the full width the other way.
+//
+// Parameters:
+//   - d: The displacement.
+//   - n: The length of the axis.
+//   - b: The boundary of the axis.
+//
+// Returns:
+//   - d, or on a Toroidal axis the equivalent displacement in (-n/2, n/2].
+func wrapOffset(d, n int, b Boundary) int {
+	if b != Toroidal {
+		return d
+	}
+	d = (d%n + n) % n
+	if d > n/2 {
+		d -= n
+	}
+	return d
+}
+
+// EqualTranslated reports whether the game's live cells form the same shape as other's,
+// possibly at a different position, by comparing the two grids within their bounding
+// boxes. The grids themselves may differ in size.
+//
+// Parameters:
+//   - other: The game to compare against.
+//
+// Returns:
+//   - equal: true if the shapes match, or if both grids are empty.
+//   - dx, dy: The rows and columns other's cells must be moved by to land on the game's
+//     cells; 0, 0 when the shapes do not match.
+func (g *Game) EqualTranslated(other *Game) (equal bool, dx, dy int) {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	oMinX, oMinY, oMaxX, oMaxY, oOK := other.BoundingBox()
+	if !ok || !oOK {
+		return ok == oOK, 0, 0
+	}
+	if maxX-minX != oMaxX-oMinX || maxY-minY != oMaxY-oMinY {
+		return false, 0, 0
+	}
+
+	for x := 0; x <= maxX-minX; x++ {
+		for y := 0; y <= maxY-minY; y++ {
+			if g.grid[minX+x][minY+y] != other.grid[oMinX+x][oMinY+y] {
+				return false, 0, 0
+			}
+		}
+	}
+
+	return true, minX - oMinX, minY - oMinY
+}
+
+// Symmetries reports which mirror symmetries the live cells have within their bounding
+// box, ignoring where on the grid the pattern lies. An empty grid is symmetric in every
+// way. Patterns that wrap across a toroidal edge are judged by their bounding box on the
+// grid, which then spans the whole axis.
+//
+// Returns:
+//   - horizontal: Whether the pattern is unchanged by FlipH, mirroring it left to right.
+//   - vertical: Whether the pattern is unchanged by FlipV, mirroring it top to bottom.
+//   - diagonal: Whether the pattern is unchanged by transposing it across the main
+//     diagonal of its bounding box, which must then be square.
+func (g *Game) Symmetries() (horizontal, vertical, diagonal bool) {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		return true, true, true
+	}
+
+	horizontal, vertical, diagonal = true, true, maxX-minX == maxY-minY
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			cell := g.grid[x][y]
+			horizontal = horizontal && cell == g.grid[x][minY+maxY-y]
+			vertical = vertical && cell == g.grid[minX+maxX-x][y]
+			diagonal = diagonal && cell == g.grid[minX+y-minY][minY+x-minX]
+		}
+	}
+	return horizontal, vertical, diagonal
+}
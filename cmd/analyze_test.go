@@ -0,0 +1,107 @@
+package main
+
+import "testing"
+
+// TestAnalyze checks the outcome Analyze reports for patterns that move, oscillate, settle
+// and die, including a glider crossing a toroidal edge and one that runs into a dead corner
+// before a period has passed and settles as a block.
+func TestAnalyze(t *testing.T) {
+	single := newEmptyGame(10, 10)
+	single.grid[3][3] = true
+
+	tests := []struct {
+		name    string
+		game    *Game
+		outcome Outcome
+		period  int
+		dx, dy  int
+		speed   string
+	}{
+		{"glider", NewGame(WithSize(20, 20), WithPatternAt("glider", 2, 2)), Spaceship, 4, 1, 1, "c/4 diagonal"},
+		{"glider across the edge", NewGame(WithSize(20, 20), WithPatternAt("glider", 18, 18)), Spaceship, 4, 1, 1, "c/4 diagonal"},
+		{"lwss", NewGame(WithSize(30, 20), WithPatternAt("lwss", 8, 4)), Spaceship, 4, 0, -2, "c/2 orthogonal"},
+		{"blinker", NewGame(WithSize(10, 10), WithPatternAt("blinker", 4, 4)), Oscillating, 2, 0, 0, ""},
+		{"block", NewGame(WithSize(10, 10), WithBoundary(Dead), WithPatternAt("glider", 7, 7)), StillLife, 1, 0, 0, ""},
+		{"single cell", single, Extinct, 0, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.game.Analyze(200, 16)
+			if a.Outcome != tt.outcome || a.Period != tt.period || a.DX != tt.dx || a.DY != tt.dy {
+				t.Errorf("Analyze() = %s with period %d moving (%d, %d), want %s with period %d moving (%d, %d)",
+					a.Outcome, a.Period, a.DX, a.DY, tt.outcome, tt.period, tt.dx, tt.dy)
+			}
+			if got := a.Speed(); got != tt.speed {
+				t.Errorf("Speed() = %q, want %q", got, tt.speed)
+			}
+		})
+	}
+}
+
+// TestEqualTranslated compares an r-pentomino with shifted copies on grids of the same and
+// of another size, with a mirrored copy and with empty grids.
+func TestEqualTranslated(t *testing.T) {
+	g := NewGame(WithSize(20, 20), WithPatternAt("rpentomino", 3, 4))
+	mirrored := NewGame(WithSize(20, 20), WithPatternAt("rpentomino", 3, 4))
+	mirrored.FlipH()
+	empty := NewGame(WithSize(20, 20), WithRandom(0, 1))
+
+	tests := []struct {
+		name   string
+		a, b   *Game
+		equal  bool
+		dx, dy int
+	}{
+		{"same position", g, NewGame(WithSize(20, 20), WithPatternAt("rpentomino", 3, 4)), true, 0, 0},
+		{"shifted copy", g, NewGame(WithSize(20, 20), WithPatternAt("rpentomino", 10, 1)), true, -7, 3},
+		{"shifted copy on a larger grid", g, NewGame(WithSize(40, 30), WithPatternAt("rpentomino", 25, 30)), true, -22, -26},
+		{"mirrored copy", g, mirrored, false, 0, 0},
+		{"one grid empty", g, empty, false, 0, 0},
+		{"both grids empty", empty, NewGame(WithSize(5, 5), WithRandom(0, 1)), true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, dx, dy := tt.a.EqualTranslated(tt.b)
+			if equal != tt.equal || dx != tt.dx || dy != tt.dy {
+				t.Errorf("EqualTranslated() = %v, %d, %d, want %v, %d, %d", equal, dx, dy, tt.equal, tt.dx, tt.dy)
+			}
+		})
+	}
+}
+
+// TestSymmetries checks the mirror symmetries of a block, a glider, a blinker, an
+// r-pentomino and an empty grid.
+func TestSymmetries(t *testing.T) {
+	tests := []struct {
+		name                           string
+		pattern                        string
+		horizontal, vertical, diagonal bool
+	}{
+		{"block", "", true, true, true},
+		{"glider", "glider", false, false, false},
+		{"blinker", "blinker", true, true, false},
+		{"rpentomino", "rpentomino", false, false, false},
+		{"beacon", "beacon", false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 10), WithRandom(0, 1))
+			if tt.pattern == "" {
+				g.SetLiveCells([][2]int{{4, 4}, {4, 5}, {5, 4}, {5, 5}})
+			} else if err := InsertPattern(g, tt.pattern, 3, 3); err != nil {
+				t.Fatal(err)
+			}
+			h, v, d := g.Symmetries()
+			if h != tt.horizontal || v != tt.vertical || d != tt.diagonal {
+				t.Errorf("Symmetries() = %v, %v, %v, want %v, %v, %v", h, v, d, tt.horizontal, tt.vertical, tt.diagonal)
+			}
+		})
+	}
+
+	if h, v, d := NewGame(WithSize(5, 5), WithRandom(0, 1)).Symmetries(); !h || !v || !d {
+		t.Errorf("Symmetries() of an empty grid = %v, %v, %v, want true, true, true", h, v, d)
+	}
+}
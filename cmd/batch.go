@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// BatchConfig configures a call to BatchRun.
+type BatchConfig struct {
+	// Options build the grid of every run, e.g. WithSize and WithRule. A WithRandom option
+	// for the run's seed is appended, so the options should not place a pattern. Each
+	// game computes its generations on a single goroutine unless the options include
+	// WithWorkers, since the runs themselves already keep every CPU busy.
+	Options []Option
+
+	// Density is the probability that a cell starts alive.
+	Density float64
+
+	// Seed is the random seed of the first run; run i uses Seed+i, so a batch can be
+	// reproduced from its base seed alone.
+	Seed int64
+
+	// MaxGenerations caps each run as in Game.Analyze; 0 selects generations rather than
+	// no limit, so that a chaotic grid cannot stall the batch.
+	MaxGenerations int
+
+	// History is the number of past generations remembered when looking for a repeat.
+	History int
+
+	// Workers is the number of runs analyzed concurrently, 0 for one per CPU.
+	Workers int
+}
+
+// RunResult is the outcome of one run of a batch.
+type RunResult struct {
+	// Seed is the random seed the run's grid was filled with.
+	Seed int64
+
+	// Analysis describes how the run ended: its outcome, the generation at which it
+	// settled and its final population.
+	Analysis Analysis
+}
+
+// BatchRun fills n grids at random from consecutive seeds and analyzes each without
+// drawing, recording when and how it settled. The runs are spread over cfg.Workers
+// goroutines. Every run draws its grid from its own seed, so the results are the same
+// whatever the number of workers and the order the runs finish in.
+//
+// Parameters:
+//   - n: The number of runs.
+//   - cfg: The batch configuration.
+//
+// Returns:
+//   - The results of the runs, in seed order.
+//   - An error if cfg does not describe a valid game or cfg.Workers is negative; no runs
+//     are made.
+func BatchRun(n int, cfg BatchConfig) ([]RunResult, error) {
+	if cfg.Workers < 0 {
+		return nil, fmt.Errorf("batch: invalid worker count %d", cfg.Workers)
+	}
+	maxGenerations := cfg.MaxGenerations
+	if maxGenerations == 0 {
+		maxGenerations = generations
+	}
+	options := slices.Concat([]Option{WithWorkers(1)}, cfg.Options)
+	build := func(seed int64) (*Game, error) {
+		return BuildGame(append(slices.Clip(options), WithRandom(cfg.Density, seed))...)
+	}
+
+	// Every seed builds a game from the same options, so one build validates them all.
+	if _, err := build(cfg.Seed); err != nil {
+		return nil, fmt.Errorf("batch: %w", err)
+	}
+
+	n = max(n, 0)
+	workers := cfg.Workers
+	if workers == 0 {
+		workers = runtime.NumCPU()
+	}
+	results := make([]RunResult, n)
+	runs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range runs {
+				seed := cfg.Seed + int64(i)
+				g, _ := build(seed) // validated above
+				results[i] = RunResult{Seed: seed, Analysis: g.Analyze(maxGenerations, cfg.History)}
+			}
+		}()
+	}
+	for i := range n {
+		runs <- i
+	}
+	close(runs)
+	wg.Wait()
+
+	return results, nil
+}
+
+// writeBatchReport writes one line per run followed by the number of runs with each
+// outcome and the mean settling generation and final population. Runs that hit the
+// generation cap count as settling at the cap.
+//
+// Parameters:
+//   - w: The writer receiving the report.
+//   - results: The results returned by BatchRun.
+//
+// Returns:
+//   - An error if writing to w fails.
+func writeBatchReport(w io.Writer, results []RunResult) error {
+	var counts [Spaceship + 1]int
+	start, population := 0, 0
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "Seed %d: %v\n", r.Seed, r.Analysis); err != nil {
+			return err
+		}
+		counts[r.Analysis.Outcome]++
+		start += r.Analysis.Start
+		population += r.Analysis.Population
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w, "%d runs: %d extinct, %d still lifes, %d oscillating, %d spaceships, %d capped\n"+
+		"Mean settling generation: %.1f | Mean final population: %.1f\n",
+		len(results), counts[Extinct], counts[StillLife], counts[Oscillating], counts[Spaceship], counts[Capped],
+		float64(start)/float64(len(results)), float64(population)/float64(len(results)))
+	return err
+}
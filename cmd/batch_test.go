@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestBatchRunWorkersMatchSerial checks that a batch spread over several goroutines
+// returns the same results, in the same seed order, as one analyzing every run in turn.
+func TestBatchRunWorkersMatchSerial(t *testing.T) {
+	cfg := BatchConfig{
+		Options:        []Option{WithSize(20, 20)},
+		Density:        0.3,
+		Seed:           100,
+		MaxGenerations: 300,
+		History:        100,
+		Workers:        1,
+	}
+	serial, err := BatchRun(16, cfg)
+	if err != nil {
+		t.Fatalf("BatchRun() error = %v", err)
+	}
+	for i, result := range serial {
+		if result.Seed != cfg.Seed+int64(i) {
+			t.Fatalf("result %d has seed %d, want %d", i, result.Seed, cfg.Seed+int64(i))
+		}
+	}
+
+	for _, workers := range []int{0, 3} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			cfg.Workers = workers
+			parallel, err := BatchRun(16, cfg)
+			if err != nil {
+				t.Fatalf("BatchRun() error = %v", err)
+			}
+			if !reflect.DeepEqual(parallel, serial) {
+				t.Errorf("results differ from a single worker:\n%v\nwant\n%v", parallel, serial)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// binaryMagic starts every binary snapshot, followed by a format version byte.
+const (
+	binaryMagic   = "GoL"
+	binaryVersion = 2
+)
+
+// MarshalBinary encodes the game as a compact snapshot: the magic "GoL" and a version
+// byte, the width, height and generation as unsigned varints, the birth and survival
+// sets as 9-bit big-endian masks, one byte for the neighborhood and one for each axis's
+// boundary, and finally the cells packed eight to a byte in row-major order, least
+// significant bit first. Encoding the same state always yields the same bytes.
+//
+// Returns:
+//   - The binary encoding of the game.
+//   - A nil error; the signature satisfies encoding.BinaryMarshaler.
+func (g *Game) MarshalBinary() ([]byte, error) {
+	width, height := g.grid.Width(), g.grid.Height()
+	data := make([]byte, 0, len(binaryMagic)+1+3*binary.MaxVarintLen64+7+(width*height+7)/8)
+
+	data = append(data, binaryMagic...)
+	data = append(data, binaryVersion)
+	data = binary.AppendUvarint(data, uint64(width))
+	data = binary.AppendUvarint(data, uint64(height))
+	data = binary.AppendUvarint(data, uint64(g.gen))
+	data = binary.BigEndian.AppendUint16(data, countMask(g.rule.Birth))
+	data = binary.BigEndian.AppendUint16(data, countMask(g.rule.Survival))
+	data = append(data, byte(g.neighborhood), byte(g.boundaryX), byte(g.boundaryY))
+
+	cells := make([]byte, (width*height+7)/8)
+	for x, row := range g.grid {
+		for y, alive := range row {
+			if i := x*width + y; alive {
+				cells[i/8] |= 1 << (i % 8)
+			}
+		}
+	}
+
+	return append(data, cells...), nil
+}
+
+// UnmarshalBinary restores a snapshot written by MarshalBinary, replacing the game's
+// grid, generation, rule, neighborhood and boundaries and discarding its statistics.
+// Display and worker settings are kept.
+//
+// Parameters:
+//   - data: The binary encoding of a game.
+//
+// Returns:
+//   - An error if the data is truncated, has an unknown version or describes an invalid
+//     game; the game is left unchanged.
+func (g *Game) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic)+1 || string(data[:len(binaryMagic)]) != binaryMagic {
+		return fmt.Errorf("binary: not a game snapshot")
+	}
+	// Version 1 snapshots store a single boundary shared by both axes.
+	version := data[len(binaryMagic)]
+	if version != 1 && version != binaryVersion {
+		return fmt.Errorf("binary: unsupported version %d", version)
+	}
+	data = data[len(binaryMagic)+1:]
+
+	var header [3]uint64
+	for i := range header {
+		n, size := binary.Uvarint(data)
+		if size <= 0 {
+			return fmt.Errorf("binary: truncated header")
+		}
+		header[i], data = n, data[size:]
+	}
+	width, height, gen := header[0], header[1], header[2]
+	if width == 0 || height == 0 || width > 1<<20 || height > 1<<20 {
+		return fmt.Errorf("binary: invalid dimensions %dx%d", width, height)
+	}
+	if gen > 1<<62 {
+		return fmt.Errorf("binary: invalid generation %d", gen)
+	}
+
+	fixed := 7
+	if version == 1 {
+		fixed = 6
+	}
+	if len(data) < fixed {
+		return fmt.Errorf("binary: truncated header")
+	}
+	birth, survival := binary.BigEndian.Uint16(data), binary.BigEndian.Uint16(data[2:])
+	if birth>>9 != 0 || survival>>9 != 0 {
+		return fmt.Errorf("binary: invalid rule masks %#x/%#x", birth, survival)
+	}
+	neighborhood := Neighborhood(data[4])
+	if neighborhood != Moore && neighborhood != VonNeumann {
+		return fmt.Errorf("binary: unknown neighborhood %d", data[4])
+	}
+	boundaryX, boundaryY := Boundary(data[5]), Boundary(data[fixed-1])
+	for _, b := range [2]Boundary{boundaryX, boundaryY} {
+		if b != Toroidal && b != Dead && b != Mirror {
+			return fmt.Errorf("binary: unknown boundary %d", int(b))
+		}
+	}
+	data = data[fixed:]
+
+	w, h := int(width), int(height)
+	if len(data) != (w*h+7)/8 {
+		return fmt.Errorf("binary: %d bytes of cells, want %d for a %dx%d grid", len(data), (w*h+7)/8, w, h)
+	}
+	grid := newGrid(w, h)
+	for x, row := range grid {
+		for y := range row {
+			i := x*w + y
+			row[y] = data[i/8]&(1<<(i%8)) != 0
+		}
+	}
+
+	*g = Game{
+		grid:         grid,
+		gen:          int(gen),
+		rule:         Rule{Birth: maskCounts(birth), Survival: maskCounts(survival)},
+		neighborhood: neighborhood,
+		boundaryX:    boundaryX,
+		boundaryY:    boundaryY,
+		colorAges:    g.colorAges,
+		liveGlyph:    g.liveGlyph,
+		deadGlyph:    g.deadGlyph,
+		plain:        g.plain,
+		ghostSteps:   g.ghostSteps,
+		follow:       g.follow,
+		workers:      g.workers,
+	}
+	return nil
+}
+
+// LoadBinary reads a game snapshot written by MarshalBinary.
+//
+// Parameters:
+//   - r: The reader providing the snapshot.
+//
+// Returns:
+//   - A pointer to the restored Game struct.
+//   - An error if reading fails or the snapshot is invalid.
+func LoadBinary(r io.Reader) (*Game, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("binary: %w", err)
+	}
+
+	g := &Game{}
+	if err := g.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// countMask packs a set of neighbor counts into a bit mask with bit n set for count n.
+func countMask(counts [9]bool) uint16 {
+	var mask uint16
+	for n, ok := range counts {
+		if ok {
+			mask |= 1 << n
+		}
+	}
+	return mask
+}
+
+// maskCounts unpacks a bit mask written by countMask.
+func maskCounts(mask uint16) [9]bool {
+	var counts [9]bool
+	for n := range counts {
+		counts[n] = mask&(1<<n) != 0
+	}
+	return counts
+}
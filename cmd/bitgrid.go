@@ -0,0 +1,285 @@
+package main
+
+import "math/bits"
+
+// BitGrid is a compact alternative to Grid that stores one bit per cell, an eighth of the
+// memory of a Grid. Each row is packed into stride 64-bit words, with column y of a row
+// held in bit y%64 of word y/64; the unused high bits of a row's last word are always
+// zero. A Game computes every generation on a BitGrid copy of its grid and keeps the
+// copies it holds on to, for Reset and Undo, in this form.
+type BitGrid struct {
+	width  int
+	height int
+	stride int // words per row
+	words  []uint64
+}
+
+// NewBitGrid allocates a BitGrid of the given dimensions with all cells dead.
+//
+// Parameters:
+//   - width: The number of columns in the grid.
+//   - height: The number of rows in the grid.
+//
+// Returns:
+//   - A pointer to the allocated BitGrid.
+func NewBitGrid(width, height int) *BitGrid {
+	stride := (width + 63) / 64
+	return &BitGrid{width: width, height: height, stride: stride, words: make([]uint64, stride*height)}
+}
+
+// BitGridFrom packs a Grid into a new BitGrid with the same dimensions and cells.
+//
+// Parameters:
+//   - gr: The grid to pack.
+//
+// Returns:
+//   - A pointer to the packed BitGrid.
+func BitGridFrom(gr Grid) *BitGrid {
+	b := NewBitGrid(gr.Width(), gr.Height())
+	b.pack(gr)
+	return b
+}
+
+// pack overwrites every cell of the BitGrid with the cells of gr, which must have the
+// same dimensions.
+func (b *BitGrid) pack(gr Grid) {
+	for x, row := range gr {
+		words := b.row(x)
+		clear(words)
+		for y, cell := range row {
+			if cell {
+				words[y/64] |= 1 << (y % 64)
+			}
+		}
+	}
+}
+
+// Grid unpacks the BitGrid into a new Grid with the same dimensions and cells.
+func (b *BitGrid) Grid() Grid {
+	gr := newGrid(b.width, b.height)
+	b.copyTo(gr)
+	return gr
+}
+
+// copyTo unpacks every cell of the BitGrid into gr, which must have the same dimensions.
+func (b *BitGrid) copyTo(gr Grid) {
+	for x, row := range gr {
+		words := b.row(x)
+		for y := range row {
+			row[y] = words[y/64]&(1<<(y%64)) != 0
+		}
+	}
+}
+
+// Width returns the number of columns in the grid.
+func (b *BitGrid) Width() int {
+	return b.width
+}
+
+// Height returns the number of rows in the grid.
+func (b *BitGrid) Height() int {
+	return b.height
+}
+
+// row returns the words holding row x.
+func (b *BitGrid) row(x int) []uint64 {
+	return b.words[x*b.stride : (x+1)*b.stride]
+}
+
+// Get reports whether the cell at the given in-range coordinate is alive.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//
+// Returns:
+//   - true if the cell is alive.
+func (b *BitGrid) Get(x, y int) bool {
+	return b.words[x*b.stride+y/64]&(1<<(y%64)) != 0
+}
+
+// Set makes the cell at the given in-range coordinate alive or dead.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//   - alive: The new state of the cell.
+func (b *BitGrid) Set(x, y int, alive bool) {
+	i, mask := x*b.stride+y/64, uint64(1)<<(y%64)
+	if alive {
+		b.words[i] |= mask
+	} else {
+		b.words[i] &^= mask
+	}
+}
+
+// CountLiveCells counts the live cells with a population count over every word.
+//
+// Returns:
+//   - The count of live cells as an integer.
+func (b *BitGrid) CountLiveCells() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// NextGen computes the following generation 64 cells at a time. For each word of a row
+// the neighbors above, below and beside it are gathered as shifted copies of the
+// neighboring row words and summed with bit-sliced half adders into a 4-bit count per
+// cell, which is then matched against the rule. The result is identical to stepping a
+// Game with the same rule, neighborhood and boundaries.
+//
+// Parameters:
+//   - rule: The birth and survival rule to apply.
+//   - nh: The neighborhood whose cells are counted.
+//   - boundaryX: How row indices beyond the top and bottom edges are resolved.
+//   - boundaryY: How column indices beyond the left and right edges are resolved.
+//
+// Returns:
+//   - A pointer to a new BitGrid holding the next generation.
+func (b *BitGrid) NextGen(rule Rule, nh Neighborhood, boundaryX, boundaryY Boundary) *BitGrid {
+	next := NewBitGrid(b.width, b.height)
+	b.stepRows(next, 0, b.height, rule, nh, boundaryX, boundaryY, make([]uint64, bitScratchRows*b.stride))
+	return next
+}
+
+// bitScratchRows is the number of rows of scratch words stepRows works in: a row of dead
+// cells, and the west and east shifts of the three rows around the one being computed.
+const bitScratchRows = 7
+
+// stepRows computes rows [from, to) of the generation after b into next, like NextGen.
+// Rows are only read from b and written to next, so bands of rows can be computed
+// concurrently as long as each has its own scratch words.
+//
+// Parameters:
+//   - next: A BitGrid of the same dimensions receiving the next generation.
+//   - from: The first row to compute.
+//   - to: The row after the last one to compute.
+//   - rule: The birth and survival rule to apply.
+//   - nh: The neighborhood whose cells are counted.
+//   - boundaryX: How row indices beyond the top and bottom edges are resolved.
+//   - boundaryY: How column indices beyond the left and right edges are resolved.
+//   - scratch: At least bitScratchRows rows of words, overwritten.
+func (b *BitGrid) stepRows(next *BitGrid, from, to int, rule Rule, nh Neighborhood, boundaryX, boundaryY Boundary, scratch []uint64) {
+	if b.width == 0 || b.height == 0 {
+		return
+	}
+
+	stride := b.stride
+	zero := scratch[:stride]
+	clear(zero)
+	rowAt := func(x int) []uint64 {
+		if rx, ok := boundaryX.resolve(x, b.height); ok {
+			return b.row(rx)
+		}
+		return zero
+	}
+
+	// Scratch rows holding, for every column y, the cell at y-1 (west) and y+1 (east).
+	var west, east [3][]uint64
+	for i := range 3 {
+		west[i] = scratch[(1+i)*stride : (2+i)*stride]
+		east[i] = scratch[(4+i)*stride : (5+i)*stride]
+	}
+	lastMask := ^uint64(0) >> (uint(stride*64-b.width) % 64)
+
+	for x := from; x < to; x++ {
+		rows := [3][]uint64{rowAt(x - 1), b.row(x), rowAt(x + 1)}
+		for i, r := range rows {
+			b.shiftWest(west[i], r, boundaryY)
+			b.shiftEast(east[i], r, boundaryY)
+		}
+
+		out := next.row(x)
+		for i := range out {
+			var inputs [8]uint64
+			n := 0
+			switch nh {
+			case VonNeumann:
+				inputs[0], inputs[1], inputs[2], inputs[3] = rows[0][i], west[1][i], east[1][i], rows[2][i]
+				n = 4
+			default:
+				inputs = [8]uint64{
+					west[0][i], rows[0][i], east[0][i],
+					west[1][i], east[1][i],
+					west[2][i], rows[2][i], east[2][i],
+				}
+				n = 8
+			}
+
+			var s0, s1, s2, s3 uint64
+			for _, v := range inputs[:n] {
+				c0 := s0 & v
+				s0 ^= v
+				c1 := s1 & c0
+				s1 ^= c0
+				c2 := s2 & c1
+				s2 ^= c1
+				s3 |= c2
+			}
+
+			alive := rows[1][i]
+			var word uint64
+			for count := 0; count <= 8; count++ {
+				if !rule.Birth[count] && !rule.Survival[count] {
+					continue
+				}
+				match := ^uint64(0)
+				for bit, s := range [4]uint64{s0, s1, s2, s3} {
+					if count&(1<<bit) != 0 {
+						match &= s
+					} else {
+						match &^= s
+					}
+				}
+				if rule.Birth[count] {
+					word |= match &^ alive
+				}
+				if rule.Survival[count] {
+					word |= match & alive
+				}
+			}
+			out[i] = word
+		}
+		out[len(out)-1] &= lastMask
+	}
+}
+
+// shiftWest fills dst so that bit y holds the cell at column y-1 of row, resolving
+// column -1 through the boundary.
+//
+// Parameters:
+//   - dst: The destination words, one row long.
+//   - row: The source row words.
+//   - boundary: How the column beyond the left edge is resolved.
+func (b *BitGrid) shiftWest(dst, row []uint64, boundary Boundary) {
+	var carry uint64
+	for i, w := range row {
+		dst[i] = w<<1 | carry
+		carry = w >> 63
+	}
+	if y, ok := boundary.resolve(-1, b.width); ok && row[y/64]&(1<<(y%64)) != 0 {
+		dst[0] |= 1
+	}
+}
+
+// shiftEast fills dst so that bit y holds the cell at column y+1 of row, resolving the
+// column beyond the right edge through the boundary.
+//
+// Parameters:
+//   - dst: The destination words, one row long.
+//   - row: The source row words.
+//   - boundary: How the column beyond the right edge is resolved.
+func (b *BitGrid) shiftEast(dst, row []uint64, boundary Boundary) {
+	var carry uint64
+	for i := len(row) - 1; i >= 0; i-- {
+		dst[i] = row[i]>>1 | carry
+		carry = row[i] << 63
+	}
+	last := b.width - 1
+	if y, ok := boundary.resolve(b.width, b.width); ok && row[y/64]&(1<<(y%64)) != 0 {
+		dst[last/64] |= 1 << (last % 64)
+	}
+}
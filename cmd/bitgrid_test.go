@@ -0,0 +1,257 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// randomGrid returns a width x height Grid with each cell alive with probability density,
+// drawn from a fixed seed.
+func randomGrid(width, height int, density float64, seed int64) Grid {
+	g := newEmptyGame(width, height)
+	g.RandomFill(density, rand.New(rand.NewSource(seed)))
+	return g.grid
+}
+
+// TestBitGridMatchesGrid packs random grids whose widths do and do not fill their last
+// word and checks that every cell, the live-cell count and the unpacked grid match.
+func TestBitGridMatchesGrid(t *testing.T) {
+	for _, size := range [][2]int{{1, 1}, {63, 5}, {64, 3}, {65, 4}, {130, 9}} {
+		t.Run(fmt.Sprintf("%dx%d", size[0], size[1]), func(t *testing.T) {
+			gr := randomGrid(size[0], size[1], 0.4, 5)
+			b := BitGridFrom(gr)
+
+			if b.Width() != gr.Width() || b.Height() != gr.Height() {
+				t.Fatalf("BitGrid is %dx%d, want %dx%d", b.Width(), b.Height(), gr.Width(), gr.Height())
+			}
+			for x := range gr {
+				for y := range gr[x] {
+					if b.Get(x, y) != gr[x][y] {
+						t.Fatalf("Get(%d, %d) = %v, want %v", x, y, b.Get(x, y), gr[x][y])
+					}
+				}
+			}
+			want := (&Game{grid: gr}).CountLiveCells()
+			if got := b.CountLiveCells(); got != want {
+				t.Errorf("CountLiveCells() = %d, want %d", got, want)
+			}
+			if !b.Grid().Equal(gr) {
+				t.Error("Grid() differs from the packed grid")
+			}
+		})
+	}
+}
+
+// TestBitGridSet checks that Set changes only the addressed cell and keeps the unused
+// bits of the last word zero.
+func TestBitGridSet(t *testing.T) {
+	b := NewBitGrid(70, 2)
+	b.Set(1, 69, true)
+	b.Set(0, 0, true)
+	b.Set(0, 0, false)
+
+	if !b.Get(1, 69) || b.Get(0, 0) || b.Get(1, 68) {
+		t.Errorf("cells after Set: (1, 69) = %v, (0, 0) = %v, (1, 68) = %v, want true, false, false",
+			b.Get(1, 69), b.Get(0, 0), b.Get(1, 68))
+	}
+	if got := b.CountLiveCells(); got != 1 {
+		t.Errorf("CountLiveCells() = %d, want 1", got)
+	}
+	if words := len(b.words); words != 4 {
+		t.Errorf("a 70x2 BitGrid uses %d words, want 4", words)
+	}
+}
+
+// TestBitGridNextGenMatchesGame checks that the word-parallel NextGen gives the same
+// generations as evaluating every cell of a Game, for every neighborhood and pair of
+// boundaries and for widths that do and do not fill their last word.
+func TestBitGridNextGenMatchesGame(t *testing.T) {
+	rules := []Rule{ConwayRule, mustParseRule("B36/S23"), mustParseRule("B0/S8"), mustParseRule("B1357/S02468")}
+	boundaries := []Boundary{Toroidal, Dead, Mirror}
+	for _, size := range [][2]int{{64, 20}, {70, 33}, {130, 3}} {
+		for _, nh := range []Neighborhood{Moore, VonNeumann} {
+			for _, bx := range boundaries {
+				for _, by := range boundaries {
+					name := fmt.Sprintf("%dx%d %s %s", size[0], size[1], nh, formatBoundaries(bx, by))
+					t.Run(name, func(t *testing.T) {
+						for i, rule := range rules {
+							g := &Game{grid: randomGrid(size[0], size[1], 0.35, int64(i)), rule: rule,
+								neighborhood: nh, boundaryX: bx, boundaryY: by}
+							b := BitGridFrom(g.grid)
+							for gen := range 5 {
+								g.grid = referenceNextGen(g)
+								b = b.NextGen(rule, nh, bx, by)
+								if !b.Grid().Equal(g.grid) {
+									t.Fatalf("rule %s: generation %d differs", rule, gen+1)
+								}
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestNextGenMatchesReference checks that NextGen, recomputing only the active rows as
+// the changes spread and die down, steps exactly like evaluating every cell, with the
+// same ages and birth and death counts.
+func TestNextGenMatchesReference(t *testing.T) {
+	g := NewGame(WithSize(100, 60), WithRandom(0.4, 9), WithBoundaries(Dead, Toroidal), WithWorkers(3))
+	ages := make([][]int, g.grid.Height())
+	for x := range ages {
+		ages[x] = make([]int, g.grid.Width())
+	}
+
+	for i := range 300 {
+		if i == 150 {
+			g.Toggle(30, 50)
+		}
+		want := referenceNextGen(g)
+		births, deaths := 0, 0
+		for x := range want {
+			for y := range want[x] {
+				switch was := g.grid[x][y]; {
+				case want[x][y] && was:
+					ages[x][y]++
+				case want[x][y]:
+					births++
+					ages[x][y] = 0
+				case was:
+					deaths++
+					ages[x][y] = 0
+				}
+			}
+		}
+
+		g.NextGen()
+		if !g.grid.Equal(want) {
+			t.Fatalf("generation %d differs from a full scan", g.Generation())
+		}
+		if g.LastBirths() != births || g.LastDeaths() != deaths {
+			t.Fatalf("generation %d: births/deaths %d/%d, want %d/%d",
+				g.Generation(), g.LastBirths(), g.LastDeaths(), births, deaths)
+		}
+		for x := range ages {
+			for y := range ages[x] {
+				if g.Age(x, y) != ages[x][y] {
+					t.Fatalf("generation %d: Age(%d, %d) = %d, want %d", g.Generation(), x, y, g.Age(x, y), ages[x][y])
+				}
+			}
+		}
+	}
+}
+
+// wrapTableNextGen computes the generation after g's current one cell by cell, looking up
+// every neighbor's resolved row and column in tables built once per generation, the way
+// NextGen counted neighbors before the word-parallel kernel replaced the tables.
+func wrapTableNextGen(g *Game) Grid {
+	table := func(b Boundary, n int) []int {
+		t := make([]int, n+2)
+		for i := range t {
+			if r, ok := b.resolve(i-1, n); ok {
+				t[i] = r
+			} else {
+				t[i] = -1
+			}
+		}
+		return t
+	}
+	rows, cols := table(g.boundaryX, g.grid.Height()), table(g.boundaryY, g.grid.Width())
+
+	next := newGrid(g.grid.Width(), g.grid.Height())
+	for x := range next {
+		for y := range next[x] {
+			count := 0
+			for _, d := range neighborOffsets[g.neighborhood] {
+				rx, ry := rows[x+d[0]+1], cols[y+d[1]+1]
+				if rx >= 0 && ry >= 0 && g.grid[rx][ry] {
+					count++
+				}
+			}
+			next[x][y] = g.rule.Next(g.grid[x][y], count)
+		}
+	}
+	return next
+}
+
+// BenchmarkNextGenKernels compares computing a 1024x1024 generation cell by cell with
+// LiveNeighbors, cell by cell through precomputed wrap tables and 64 cells at a time with
+// the word-parallel kernel, which needs no tables and is the fastest of the three.
+func BenchmarkNextGenKernels(b *testing.B) {
+	g := NewGame(WithSize(1024, 1024), WithRandom(0.3, 1))
+	packed, next := BitGridFrom(g.grid), NewBitGrid(1024, 1024)
+	scratch := make([]uint64, bitScratchRows*packed.stride)
+	if !wrapTableNextGen(g).Equal(referenceNextGen(g)) {
+		b.Fatal("the wrap tables compute a different generation")
+	}
+
+	b.Run("cells", func(b *testing.B) {
+		for range b.N {
+			referenceNextGen(g)
+		}
+	})
+	b.Run("wrap tables", func(b *testing.B) {
+		for range b.N {
+			wrapTableNextGen(g)
+		}
+	})
+	b.Run("words", func(b *testing.B) {
+		for range b.N {
+			packed.stepRows(next, 0, 1024, g.rule, g.neighborhood, g.boundaryX, g.boundaryY, scratch)
+		}
+	})
+}
+
+// BenchmarkGridStorage compares counting the live cells of a 1024x1024 grid stored as a
+// Grid and as a BitGrid, reporting the bytes each takes for its cells.
+func BenchmarkGridStorage(b *testing.B) {
+	gr := randomGrid(1024, 1024, 0.3, 1)
+	packed := BitGridFrom(gr)
+	g := &Game{grid: gr}
+
+	b.Run("bool", func(b *testing.B) {
+		for range b.N {
+			g.CountLiveCells()
+		}
+		b.ReportMetric(float64(gr.Width()*gr.Height()), "B/grid")
+	})
+	b.Run("bits", func(b *testing.B) {
+		for range b.N {
+			packed.CountLiveCells()
+		}
+		b.ReportMetric(float64(8*len(packed.words)), "B/grid")
+	})
+}
+
+// BenchmarkGridGet compares reading every cell of a 1024x1024 Grid and BitGrid.
+func BenchmarkGridGet(b *testing.B) {
+	gr := randomGrid(1024, 1024, 0.3, 1)
+	packed := BitGridFrom(gr)
+
+	b.Run("bool", func(b *testing.B) {
+		for range b.N {
+			count := 0
+			for x := range gr {
+				for y := range gr[x] {
+					if gr[x][y] {
+						count++
+					}
+				}
+			}
+		}
+	})
+	b.Run("bits", func(b *testing.B) {
+		for range b.N {
+			count := 0
+			for x := range packed.Height() {
+				for y := range packed.Width() {
+					if packed.Get(x, y) {
+						count++
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Boundary selects how neighbor lookups behave beyond the edges of the grid.
+type Boundary int
+
+const (
+	// Toroidal wraps indices around to the opposite edge.
+	Toroidal Boundary = iota
+
+	// Dead treats every cell beyond the edge as permanently dead.
+	Dead
+
+	// Mirror reflects indices back across the edge, so the cell just beyond an edge
+	// mirrors the edge cell itself.
+	Mirror
+)
+
+// ParseBoundary parses a boundary name ("toroidal", "dead" or "mirror", case-insensitive).
+//
+// Parameters:
+//   - s: The boundary name.
+//
+// Returns:
+//   - The parsed Boundary.
+//   - An error if the name is unknown.
+func ParseBoundary(s string) (Boundary, error) {
+	switch strings.ToLower(s) {
+	case "toroidal", "torus", "wrap":
+		return Toroidal, nil
+	case "dead":
+		return Dead, nil
+	case "mirror", "reflect":
+		return Mirror, nil
+	default:
+		return 0, fmt.Errorf("unknown boundary %q (want toroidal, dead or mirror)", s)
+	}
+}
+
+// ParseBoundaries parses a boundary for each axis: either a single boundary name used for
+// both axes, a "rows,columns" pair such as "dead,toroidal", or "cylinder", shorthand for
+// dead rows and toroidal columns (the grid wraps left to right but not top to bottom).
+//
+// Parameters:
+//   - s: The boundary specification.
+//
+// Returns:
+//   - The boundaries of the row (x) and column (y) axes.
+//   - An error if a name is unknown.
+func ParseBoundaries(s string) (x, y Boundary, err error) {
+	if strings.EqualFold(s, "cylinder") {
+		return Dead, Toroidal, nil
+	}
+
+	rows, cols, pair := strings.Cut(s, ",")
+	if x, err = ParseBoundary(strings.TrimSpace(rows)); err != nil {
+		return 0, 0, err
+	}
+	if !pair {
+		return x, x, nil
+	}
+	if y, err = ParseBoundary(strings.TrimSpace(cols)); err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+// formatBoundaries formats per-axis boundaries the way ParseBoundaries reads them: a
+// single name when both axes agree, otherwise a "rows,columns" pair.
+func formatBoundaries(x, y Boundary) string {
+	if x == y {
+		return x.String()
+	}
+	return x.String() + "," + y.String()
+}
+
+// String returns the lower-case name of the boundary.
+func (b Boundary) String() string {
+	switch b {
+	case Toroidal:
+		return "toroidal"
+	case Dead:
+		return "dead"
+	case Mirror:
+		return "mirror"
+	default:
+		return fmt.Sprintf("Boundary(%d)", int(b))
+	}
+}
+
+// resolve maps an index along an axis of length n onto the grid.
+//
+// Parameters:
+//   - i: The index to resolve, which may lie outside [0, n).
+//   - n: The length of the axis.
+//
+// Returns:
+//   - The in-range index.
+//   - false if the index falls outside the grid and the boundary treats it as dead.
+func (b Boundary) resolve(i, n int) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+
+	switch b {
+	case Dead:
+		return 0, false
+	case Mirror:
+		// Reflect without repeating the edge: -1 maps to 0 and n maps to n-1.
+		period := 2 * n
+		i = (i%period + period) % period
+		if i >= n {
+			i = period - 1 - i
+		}
+		return i, true
+	default:
+		return (i%n + n) % n, true
+	}
+}
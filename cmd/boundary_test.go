@@ -0,0 +1,97 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDeadBoundaryGlider runs a glider into the bottom-right corner of a grid with dead
+// edges and checks that it never reappears at the top or left, as it does on a torus, but
+// leaves only the block it collapses into.
+func TestDeadBoundaryGlider(t *testing.T) {
+	dead := NewGame(WithSize(10, 10), WithBoundary(Dead), WithPatternAt("glider", 4, 4))
+	torus := NewGame(WithSize(10, 10), WithPatternAt("glider", 4, 4))
+	start := torus.Clone()
+
+	for range 40 {
+		dead.NextGen()
+		torus.NextGen()
+		dead.ForEachLive(func(x, y int) {
+			if x < 4 || y < 4 {
+				t.Fatalf("generation %d: cell (%d, %d) wrapped across a dead edge", dead.Generation(), x, y)
+			}
+		})
+	}
+
+	block := [][2]int{{8, 8}, {8, 9}, {9, 8}, {9, 9}}
+	if got := dead.LiveCells(); !slices.Equal(got, block) {
+		t.Errorf("LiveCells() after 40 generations = %v, want the corner block %v", got, block)
+	}
+	if !torus.Equal(start) {
+		t.Error("on a torus the glider did not wrap back to its start after 40 generations")
+	}
+}
+
+// TestBoundaryResolve checks how each boundary maps the indices just beyond both ends of
+// an axis.
+func TestBoundaryResolve(t *testing.T) {
+	tests := []struct {
+		boundary Boundary
+		i        int
+		want     int
+		ok       bool
+	}{
+		{Toroidal, -1, 4, true},
+		{Toroidal, 5, 0, true},
+		{Dead, -1, 0, false},
+		{Dead, 5, 0, false},
+		{Mirror, -1, 0, true},
+		{Mirror, 5, 4, true},
+		{Mirror, 2, 2, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.boundary.resolve(tt.i, 5)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s.resolve(%d, 5) = %d, %v, want %d, %v", tt.boundary, tt.i, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// TestLiveNeighborsBoundaries counts the neighbors of cells next to a line lying against
+// an edge of a 5x5 grid, for mirror edges and for axes with different boundaries. A
+// mirror edge reflects the cells beyond it back onto the edge, so a cell on the edge
+// counts its row or column neighbors twice and itself once.
+func TestLiveNeighborsBoundaries(t *testing.T) {
+	column := [][2]int{{1, 0}, {2, 0}, {3, 0}} // against the left edge
+	row := [][2]int{{0, 1}, {0, 2}, {0, 3}}    // against the top edge
+	tests := []struct {
+		name     string
+		x, y     Boundary
+		live     [][2]int
+		cx, cy   int
+		expected int
+	}{
+		{"mirror, middle of the line", Mirror, Mirror, column, 2, 0, 5},
+		{"mirror, corner above the line", Mirror, Mirror, column, 0, 0, 2},
+		{"dead, middle of the line", Dead, Dead, column, 2, 0, 2},
+		{"toroidal, across the right edge", Toroidal, Toroidal, column, 2, 4, 3},
+		{"mirror rows, toroidal columns", Mirror, Toroidal, column, 2, 4, 3},
+		{"toroidal rows, mirror columns", Toroidal, Mirror, column, 2, 4, 0},
+		{"dead rows, toroidal columns", Dead, Toroidal, row, 4, 2, 0},
+		{"toroidal rows, dead columns", Toroidal, Dead, row, 4, 2, 3},
+		{"mirror rows, dead columns", Mirror, Dead, row, 0, 2, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(5, 5), WithBoundaries(tt.x, tt.y), WithRandom(0, 1))
+			for _, c := range tt.live {
+				g.Set(c[0], c[1], true)
+			}
+			if got := g.LiveNeighbors(tt.cx, tt.cy); got != tt.expected {
+				t.Errorf("LiveNeighbors(%d, %d) = %d, want %d", tt.cx, tt.cy, got, tt.expected)
+			}
+		})
+	}
+}
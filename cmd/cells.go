@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LoadCells reads a pattern in the plaintext ".cells" format and returns a Game with the
+// pattern centered on a grid of at least defaultGridSize x defaultGridSize, enlarged to
+// fit the pattern.
+//
+// Lines starting with '!' are comments. Every other line is a row of the pattern where
+// 'O' or '*' is a live cell and '.' is a dead cell. Rows shorter than the longest row are
+// padded with dead cells.
+//
+// Parameters:
+//   - r: The reader providing the plaintext data.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error on unexpected characters.
+func LoadCells(r io.Reader) (*Game, error) {
+	scanner := bufio.NewScanner(r)
+	var cells [][2]int
+	width, height, row := 0, 0, 0
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.HasPrefix(line, "!") {
+			continue
+		}
+
+		for col, ch := range []rune(line) {
+			switch ch {
+			case 'O', '*':
+				cells = append(cells, [2]int{row, col})
+			case '.':
+			default:
+				return nil, fmt.Errorf("cells: unexpected character %q on row %d", ch, row)
+			}
+		}
+
+		row++
+		if line != "" {
+			width, height = max(width, len([]rune(line))), row
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cells: %w", err)
+	}
+
+	g := newEmptyGame(max(width, defaultGridSize), max(height, defaultGridSize))
+	if err := placeCentered(g, width, height, cells); err != nil {
+		return nil, fmt.Errorf("cells: %w", err)
+	}
+
+	return g, nil
+}
+
+// WriteCells encodes the live region of the grid in the plaintext ".cells" format read by
+// LoadCells: a comment line giving the pattern's width and height, followed by one line
+// per row of the live cells' bounding box with 'O' for a live cell and '.' for a dead one.
+// An empty grid is written as the comment line alone, giving the full grid dimensions.
+//
+// Parameters:
+//   - w: The writer receiving the plaintext data.
+//
+// Returns:
+//   - An error if writing to w fails.
+func (g *Game) WriteCells(w io.Writer) error {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		_, err := fmt.Fprintf(w, "! %dx%d\n", g.grid.Width(), g.grid.Height())
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "! %dx%d\n", maxY-minY+1, maxX-minX+1)
+	for x := minX; x <= maxX; x++ {
+		for _, alive := range g.grid[x][minY : maxY+1] {
+			if alive {
+				bw.WriteByte('O')
+			} else {
+				bw.WriteByte('.')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
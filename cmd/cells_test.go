@@ -0,0 +1,97 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestLoadCellsBlinker loads a plaintext blinker and checks that it is centered on the
+// grid and oscillates.
+func TestLoadCellsBlinker(t *testing.T) {
+	const blinker = "!Name: Blinker\n.O.\n.O.\n.O.\n"
+
+	g, err := LoadCells(strings.NewReader(blinker))
+	if err != nil {
+		t.Fatalf("LoadCells() error = %v", err)
+	}
+	c := defaultGridSize / 2
+	vertical := [][2]int{{c - 1, c}, {c, c}, {c + 1, c}}
+	if got := g.LiveCells(); !slices.Equal(got, vertical) {
+		t.Fatalf("LiveCells() = %v, want %v", got, vertical)
+	}
+
+	g.NextGen()
+	horizontal := [][2]int{{c, c - 1}, {c, c}, {c, c + 1}}
+	if got := g.LiveCells(); !slices.Equal(got, horizontal) {
+		t.Errorf("LiveCells() after one generation = %v, want %v", got, horizontal)
+	}
+}
+
+// TestCenterOn checks that a loaded pattern is moved onto a grid of another size with its
+// bounding box centered, and rejected if it does not fit.
+func TestCenterOn(t *testing.T) {
+	g, err := LoadCells(strings.NewReader("OOO\n"))
+	if err != nil {
+		t.Fatalf("LoadCells() error = %v", err)
+	}
+
+	if err := centerOn(g, 60, 40); err != nil {
+		t.Fatalf("centerOn(60, 40) error = %v", err)
+	}
+	if g.grid.Width() != 60 || g.grid.Height() != 40 {
+		t.Errorf("grid is %dx%d, want 60x40", g.grid.Width(), g.grid.Height())
+	}
+	want := [][2]int{{19, 28}, {19, 29}, {19, 30}}
+	if got := g.LiveCells(); !slices.Equal(got, want) {
+		t.Errorf("LiveCells() = %v, want %v", got, want)
+	}
+
+	if err := centerOn(g, 2, 2); err == nil {
+		t.Error("centerOn(2, 2) error = nil, want an error for a 3x1 pattern")
+	}
+	if g.grid.Width() != 60 || g.grid.Height() != 40 {
+		t.Errorf("grid is %dx%d after a failed centerOn, want 60x40", g.grid.Width(), g.grid.Height())
+	}
+}
+
+// TestCellsRoundTrip writes patterns wider and taller than defaultGridSize with
+// WriteCells and checks that LoadCells reads back the same live cells, relative to their
+// bounding box, on a grid large enough to hold them.
+func TestCellsRoundTrip(t *testing.T) {
+	gun := NewGame(WithSize(60, 40), WithPatternAt("gosperglidergun", 5, 5))
+	random := NewGame(WithSize(70, 45), WithRandom(0.3, 9))
+
+	for name, g := range map[string]*Game{"gosper glider gun": gun, "random 70x45": random} {
+		t.Run(name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := g.WriteCells(&buf); err != nil {
+				t.Fatalf("WriteCells() error = %v", err)
+			}
+			loaded, err := LoadCells(strings.NewReader(buf.String()))
+			if err != nil {
+				t.Fatalf("LoadCells() error = %v", err)
+			}
+
+			minX, minY, maxX, maxY, _ := g.BoundingBox()
+			if loaded.grid.Width() < maxY-minY+1 || loaded.grid.Height() < maxX-minX+1 {
+				t.Fatalf("loaded grid is %dx%d, smaller than the %dx%d pattern",
+					loaded.grid.Width(), loaded.grid.Height(), maxY-minY+1, maxX-minX+1)
+			}
+			if got, want := boxCells(loaded), boxCells(g); !slices.Equal(got, want) {
+				t.Errorf("loaded %d live cells that differ from the %d written", len(got), len(want))
+			}
+		})
+	}
+}
+
+// boxCells returns the game's live cells as offsets from the top-left corner of their
+// bounding box.
+func boxCells(g *Game) [][2]int {
+	minX, minY, _, _, _ := g.BoundingBox()
+	cells := g.LiveCells()
+	for i := range cells {
+		cells[i] = [2]int{cells[i][0] - minX, cells[i][1] - minY}
+	}
+	return cells
+}
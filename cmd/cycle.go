@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Hash computes a 64-bit FNV-1a digest of the grid dimensions and its cells, packed 64
+// to a word row by row as in BitGrid. Identical grids always produce the same hash, and
+// since every cell position contributes its own bit, a pattern and a translated copy of
+// it hash differently.
+//
+// Returns:
+//   - The digest of the current grid state.
+func (g *Game) Hash() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint32(buf[:4], uint32(g.grid.Width()))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(g.grid.Height()))
+	h.Write(buf[:])
+
+	for _, row := range g.grid {
+		for start := 0; start < len(row); start += 64 {
+			var word uint64
+			for i, cell := range row[start:min(start+64, len(row))] {
+				if cell {
+					word |= 1 << i
+				}
+			}
+			binary.LittleEndian.PutUint64(buf[:], word)
+			h.Write(buf[:])
+		}
+	}
+
+	return h.Sum64()
+}
+
+// Hash computes a 64-bit FNV-1a digest of the coordinates of every live cell, visited in
+// sorted order so that equal universes hash equally regardless of map iteration order.
+//
+// Returns:
+//   - The digest of the current live cells.
+func (s *SparseGame) Hash() uint64 {
+	h := fnv.New64a()
+	var buf [16]byte
+	s.ForEachLive(func(x, y int) {
+		binary.LittleEndian.PutUint64(buf[:8], uint64(x))
+		binary.LittleEndian.PutUint64(buf[8:], uint64(y))
+		h.Write(buf[:])
+	})
+
+	return h.Sum64()
+}
+
+// ShapeHash computes a 64-bit FNV-1a digest of the live cells relative to their bounding
+// box, so that a pattern hashes the same wherever it sits on the grid. An empty grid
+// hashes as a 0x0 shape.
+//
+// Returns:
+//   - The digest of the current live shape.
+func (g *Game) ShapeHash() uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		h.Write(buf[:])
+		return h.Sum64()
+	}
+
+	binary.LittleEndian.PutUint32(buf[:4], uint32(maxY-minY+1))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(maxX-minX+1))
+	h.Write(buf[:])
+
+	for x := minX; x <= maxX; x++ {
+		row := g.grid[x][minY : maxY+1]
+		for start := 0; start < len(row); start += 64 {
+			var word uint64
+			for i, cell := range row[start:min(start+64, len(row))] {
+				if cell {
+					word |= 1 << i
+				}
+			}
+			binary.LittleEndian.PutUint64(buf[:], word)
+			h.Write(buf[:])
+		}
+	}
+
+	return h.Sum64()
+}
+
+// cycleDetector remembers the hashes of a bounded number of recent generations so
+// that a repeated state, and therefore a cycle, can be detected.
+type cycleDetector struct {
+	seen    map[uint64]sighting // hash -> where and when it was first seen
+	history []uint64            // ring buffer of remembered hashes, oldest at next
+	next    int
+}
+
+// sighting records the generation a hash was first seen at, and the position of the
+// pattern at that time for hashes that ignore position.
+type sighting struct {
+	gen    int
+	origin [2]int
+}
+
+// newCycleDetector creates a cycleDetector that remembers at most limit generations.
+//
+// Parameters:
+//   - limit: The maximum number of generations kept in history (at least 1).
+//
+// Returns:
+//   - A pointer to the initialized cycleDetector.
+func newCycleDetector(limit int) *cycleDetector {
+	limit = max(limit, 1)
+	return &cycleDetector{
+		seen:    make(map[uint64]sighting, limit),
+		history: make([]uint64, 0, limit),
+	}
+}
+
+// observe records the state hash of a generation and checks it against the history.
+// When the history is full the oldest entry is forgotten.
+//
+// Parameters:
+//   - hash: The state hash, as returned by Game.Hash.
+//   - gen: The generation the hash belongs to.
+//
+// Returns:
+//   - The cycle period (gen minus the generation the hash was first seen at).
+//   - true if the hash was already in the history.
+func (c *cycleDetector) observe(hash uint64, gen int) (period int, ok bool) {
+	period, _, ok = c.observeAt(hash, gen, [2]int{})
+	return period, ok
+}
+
+// observeAt records the hash of a generation together with the position of its pattern,
+// such as the corner of the bounding box for a ShapeHash, and checks it against the
+// history like observe.
+//
+// Parameters:
+//   - hash: The state hash.
+//   - gen: The generation the hash belongs to.
+//   - origin: The [row, column] position of the pattern.
+//
+// Returns:
+//   - The cycle period (gen minus the generation the hash was first seen at).
+//   - The displacement of origin since the hash was first seen.
+//   - true if the hash was already in the history.
+func (c *cycleDetector) observeAt(hash uint64, gen int, origin [2]int) (period int, offset [2]int, ok bool) {
+	if first, found := c.seen[hash]; found {
+		return gen - first.gen, [2]int{origin[0] - first.origin[0], origin[1] - first.origin[1]}, true
+	}
+
+	if len(c.history) < cap(c.history) {
+		c.history = append(c.history, hash)
+	} else {
+		delete(c.seen, c.history[c.next])
+		c.history[c.next] = hash
+		c.next = (c.next + 1) % len(c.history)
+	}
+	c.seen[hash] = sighting{gen: gen, origin: origin}
+
+	return 0, [2]int{}, false
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+// TestHash checks that two independently built identical grids share a hash, and that a
+// translated copy and the same cells on a larger grid hash differently.
+func TestHash(t *testing.T) {
+	g := NewGame(WithSize(8, 6), WithPatternAt("glider", 1, 2))
+	same, err := NewGameFromString(`
+........
+...X....
+....X...
+..XXX...
+........
+........`, 'X')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !same.Equal(g) || same.Hash() != g.Hash() {
+		t.Fatalf("identical grids hash to %#x and %#x", g.Hash(), same.Hash())
+	}
+
+	translated := NewGame(WithSize(8, 6), WithPatternAt("glider", 2, 2))
+	wider := NewGame(WithSize(9, 6), WithPatternAt("glider", 1, 2))
+	for name, other := range map[string]*Game{"translated copy": translated, "larger grid": wider} {
+		if other.Hash() == g.Hash() {
+			t.Errorf("the %s hashes like the original, %#x", name, g.Hash())
+		}
+	}
+}
+
+// TestSparseGameHash checks that sparse universes holding the same cells share a hash
+// whatever order the cells were set in.
+func TestSparseGameHash(t *testing.T) {
+	a, _ := NewSparseGame(ConwayRule, Moore)
+	b, _ := NewSparseGame(ConwayRule, Moore)
+	cells := patterns["rpentomino"]
+	for i := range cells {
+		a.Set(cells[i][0], cells[i][1], true)
+		b.Set(cells[len(cells)-1-i][0], cells[len(cells)-1-i][1], true)
+	}
+
+	if a.Hash() != b.Hash() {
+		t.Errorf("equal universes hash to %#x and %#x", a.Hash(), b.Hash())
+	}
+	b.Set(100, -100, true)
+	if a.Hash() == b.Hash() {
+		t.Error("universes with different cells share a hash")
+	}
+}
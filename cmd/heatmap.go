@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+)
+
+// heatStops are the colors of the heat map gradient, from cells that never changed to
+// the most active cells.
+var heatStops = []color.RGBA{
+	{0, 0, 0, 255},       // black: dormant
+	{40, 0, 140, 255},    // indigo
+	{200, 0, 60, 255},    // red
+	{255, 170, 0, 255},   // orange
+	{255, 255, 255, 255}, // white: most active
+}
+
+// heatPalette is the 256-color palette of heat map images, interpolated from heatStops.
+var heatPalette = func() color.Palette {
+	p := make(color.Palette, 256)
+	segments := len(heatStops) - 1
+	for i := range p {
+		t := float64(i) / float64(len(p)-1) * float64(segments)
+		s := min(int(t), segments-1)
+		f := t - float64(s)
+		a, b := heatStops[s], heatStops[s+1]
+		lerp := func(u, v uint8) uint8 { return uint8(float64(u) + (float64(v)-float64(u))*f + 0.5) }
+		p[i] = color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 255}
+	}
+	return p
+}()
+
+// EnableHeatmap starts counting, for every cell, how many times NextGen changes its
+// state, for WriteHeatmapPNG. Counting is off by default so that it costs nothing unless
+// requested. Enabling it again restarts the counts from zero.
+func (g *Game) EnableHeatmap() {
+	g.heat = make([][]int, g.grid.Height())
+	for x := range g.heat {
+		g.heat[x] = make([]int, g.grid.Width())
+	}
+}
+
+// WriteHeatmapPNG encodes the activity counted since EnableHeatmap as a PNG image in which
+// every cell is a cellSize x cellSize square colored by how often it changed state,
+// relative to the most active cell: black for cells that never changed through indigo,
+// red and orange to white for the most active.
+//
+// Parameters:
+//   - w: The writer receiving the PNG data.
+//   - cellSize: The edge length in pixels of a single cell (at least 1).
+//
+// Returns:
+//   - An error if the heat map is not enabled, cellSize is not positive or encoding fails.
+func (g *Game) WriteHeatmapPNG(w io.Writer, cellSize int) error {
+	if g.heat == nil {
+		return fmt.Errorf("heatmap: not enabled")
+	}
+	if cellSize < 1 {
+		return fmt.Errorf("heatmap: cell size must be positive, got %d", cellSize)
+	}
+
+	peak := 0
+	for _, row := range g.heat {
+		for _, n := range row {
+			peak = max(peak, n)
+		}
+	}
+
+	height, width := len(g.heat), g.grid.Width()
+	img := image.NewPaletted(image.Rect(0, 0, width*cellSize, height*cellSize), heatPalette)
+	for x, row := range g.heat {
+		for y, n := range row {
+			if n == 0 {
+				continue
+			}
+			index := uint8(n * (len(heatPalette) - 1) / peak)
+			for py := x * cellSize; py < (x+1)*cellSize; py++ {
+				offset := img.PixOffset(y*cellSize, py)
+				for px := 0; px < cellSize; px++ {
+					img.Pix[offset+px] = index
+				}
+			}
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// saveHeatmap writes the game's heat map to path as a PNG image.
+//
+// Parameters:
+//   - g: The game whose heat map is saved.
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func saveHeatmap(g *Game, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := g.WriteHeatmapPNG(f, defaultCellSize); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"time"
+)
+
+// defaultCellSize is the edge length in pixels of a cell in exported images.
+const defaultCellSize = 10
+
+// cellPalette is the two-color palette of exported images: dead cells are white and
+// live cells are black.
+var cellPalette = color.Palette{color.White, color.Black}
+
+// frame renders the grid as a two-color paletted image in which every cell is a cellSize x cellSize
+// square, white for dead cells and black for live cells.
+//
+// Parameters:
+//   - cellSize: The edge length in pixels of a single cell (at least 1).
+//
+// Returns:
+//   - An image of width*cellSize by height*cellSize pixels.
+func (g *Game) frame(cellSize int) *image.Paletted {
+	width, height := g.grid.Width(), g.grid.Height()
+	img := image.NewPaletted(image.Rect(0, 0, width*cellSize, height*cellSize), cellPalette)
+
+	for x, row := range g.grid {
+		for y, cell := range row {
+			if !cell {
+				continue
+			}
+			for py := x * cellSize; py < (x+1)*cellSize; py++ {
+				offset := img.PixOffset(y*cellSize, py)
+				for px := 0; px < cellSize; px++ {
+					img.Pix[offset+px] = 1
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// WritePNG encodes the current generation as a PNG image in which every live cell is a
+// black cellSize x cellSize square on a white background.
+//
+// Parameters:
+//   - w: The writer receiving the PNG data.
+//   - cellSize: The edge length in pixels of a single cell (at least 1).
+//
+// Returns:
+//   - An error if cellSize is not positive or encoding fails.
+func (g *Game) WritePNG(w io.Writer, cellSize int) error {
+	if cellSize < 1 {
+		return fmt.Errorf("png: cell size must be positive, got %d", cellSize)
+	}
+	return png.Encode(w, g.frame(cellSize))
+}
+
+// gifRecorder collects generations as the frames of an animated GIF.
+type gifRecorder struct {
+	anim     gif.GIF
+	cellSize int
+	delay    int // frame delay in hundredths of a second
+	limit    int // maximum number of frames, 0 for no limit
+}
+
+// newGIFRecorder creates a gifRecorder.
+//
+// Parameters:
+//   - cellSize: The edge length in pixels of a single cell (at least 1).
+//   - delay: The time each frame is shown, rounded to hundredths of a second.
+//   - limit: The maximum number of frames to record, 0 for no limit.
+//
+// Returns:
+//   - A pointer to the initialized gifRecorder.
+func newGIFRecorder(cellSize int, delay time.Duration, limit int) *gifRecorder {
+	return &gifRecorder{
+		cellSize: max(cellSize, 1),
+		delay:    int(delay / (10 * time.Millisecond)),
+		limit:    limit,
+	}
+}
+
+// add appends the game's current generation as a frame unless the frame limit is reached.
+//
+// Parameters:
+//   - g: The game whose current generation is recorded.
+func (r *gifRecorder) add(g *Game) {
+	if r.limit > 0 && len(r.anim.Image) >= r.limit {
+		return
+	}
+	r.anim.Image = append(r.anim.Image, g.frame(r.cellSize))
+	r.anim.Delay = append(r.anim.Delay, r.delay)
+}
+
+// encode writes the recorded frames to w as an endlessly looping animated GIF.
+//
+// Parameters:
+//   - w: The writer receiving the GIF data.
+//
+// Returns:
+//   - An error if no frames were recorded or encoding fails.
+func (r *gifRecorder) encode(w io.Writer) error {
+	if len(r.anim.Image) == 0 {
+		return fmt.Errorf("gif: no frames recorded")
+	}
+	return gif.EncodeAll(w, &r.anim)
+}
+
+// save writes the recorded frames to the file at path.
+//
+// Parameters:
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func (r *gifRecorder) save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := r.encode(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// WriteGIF renders the current generation followed by the next frames-1 generations as
+// an animated GIF. The generations are computed on a clone, so the game itself is not
+// advanced.
+//
+// Parameters:
+//   - w: The writer receiving the GIF data.
+//   - frames: The number of frames to write (at least 1).
+//   - delay: The time each frame is shown, rounded to hundredths of a second.
+//   - cellSize: The edge length in pixels of a single cell (at least 1).
+//
+// Returns:
+//   - An error if frames or cellSize is not positive or encoding fails.
+func (g *Game) WriteGIF(w io.Writer, frames int, delay time.Duration, cellSize int) error {
+	if frames < 1 {
+		return fmt.Errorf("gif: frame count must be positive, got %d", frames)
+	}
+	if cellSize < 1 {
+		return fmt.Errorf("gif: cell size must be positive, got %d", cellSize)
+	}
+
+	rec := newGIFRecorder(cellSize, delay, frames)
+	sim := g.Clone()
+	for i := 0; i < frames; i++ {
+		if i > 0 {
+			sim.NextGen()
+		}
+		rec.add(sim)
+	}
+
+	return rec.encode(w)
+}
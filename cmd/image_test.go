@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+// TestWritePNG decodes the PNG of a grid with one live cell and checks the image size and
+// that exactly the pixels of that cell are black.
+func TestWritePNG(t *testing.T) {
+	const cellSize = 4
+	g := NewGame(WithSize(6, 5), WithRandom(0, 1))
+	g.Set(2, 3, true)
+
+	var buf bytes.Buffer
+	if err := g.WritePNG(&buf, cellSize); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoding the PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 6*cellSize || b.Dy() != 5*cellSize {
+		t.Fatalf("image is %dx%d, want %dx%d", b.Dx(), b.Dy(), 6*cellSize, 5*cellSize)
+	}
+
+	for py := range 5 * cellSize {
+		for px := range 6 * cellSize {
+			live := py/cellSize == 2 && px/cellSize == 3
+			want := color.GrayModel.Convert(color.White)
+			if live {
+				want = color.GrayModel.Convert(color.Black)
+			}
+			if got := color.GrayModel.Convert(img.At(px, py)); got != want {
+				t.Fatalf("pixel (%d, %d) = %v, want %v", px, py, got, want)
+			}
+		}
+	}
+
+	if err := g.WritePNG(&buf, 0); err == nil {
+		t.Error("WritePNG with cell size 0 returned no error")
+	}
+}
+
+// TestWriteGIF writes three frames of a blinker and checks the frame count, the delays and
+// that the frames alternate between its two phases without advancing the game.
+func TestWriteGIF(t *testing.T) {
+	g := NewGame(WithSize(5, 5), WithPatternAt("blinker", 1, 1))
+
+	var buf bytes.Buffer
+	if err := g.WriteGIF(&buf, 3, 50*time.Millisecond, 2); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("decoding the GIF: %v", err)
+	}
+	if len(anim.Image) != 3 {
+		t.Fatalf("GIF has %d frames, want 3", len(anim.Image))
+	}
+	for i, delay := range anim.Delay {
+		if delay != 5 {
+			t.Errorf("frame %d delay = %d, want 5", i, delay)
+		}
+	}
+	if !bytes.Equal(anim.Image[0].Pix, anim.Image[2].Pix) || bytes.Equal(anim.Image[0].Pix, anim.Image[1].Pix) {
+		t.Error("frames do not alternate between the blinker's two phases")
+	}
+	if g.Generation() != 0 {
+		t.Errorf("Generation() after WriteGIF = %d, want 0", g.Generation())
+	}
+}
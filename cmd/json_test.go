@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestJSONResume saves a game at generation 50, loads it back and checks that the
+// resumed game keeps matching a game that was never saved.
+func TestJSONResume(t *testing.T) {
+	options := []Option{WithSize(30, 20), WithRule(mustParseRule("B36/S23")), WithRandom(0.35, 7)}
+	original := NewGame(options...)
+	original.Step(50)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	resumed, err := LoadJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadJSON() error = %v", err)
+	}
+	if resumed.Generation() != 50 || resumed.rule != original.rule || !resumed.Equal(original) {
+		t.Fatalf("resumed game at generation %d with rule %s differs from the original",
+			resumed.Generation(), resumed.rule)
+	}
+
+	fresh := NewGame(options...)
+	fresh.Step(50)
+	for range 50 {
+		resumed.NextGen()
+		fresh.NextGen()
+		if !resumed.Equal(fresh) {
+			t.Fatalf("resumed game differs from a fresh run at generation %d", fresh.Generation())
+		}
+	}
+	if resumed.Generation() != fresh.Generation() {
+		t.Errorf("resumed game is at generation %d, want %d", resumed.Generation(), fresh.Generation())
+	}
+}
+
+// TestLoadJSONErrors checks that snapshots describing invalid games, including grids too
+// large to allocate, are rejected.
+func TestLoadJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed", `{"width": 3`},
+		{"zero width", `{"width": 0, "height": 3, "rule": "B3/S23"}`},
+		{"huge grid", `{"width": 100000000, "height": 100000000, "rule": "B3/S23"}`},
+		{"negative generation", `{"width": 3, "height": 3, "generation": -1, "rule": "B3/S23"}`},
+		{"cell outside the grid", `{"width": 3, "height": 3, "rule": "B3/S23", "cells": [[3, 0]]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadJSON(strings.NewReader(tt.data)); err == nil {
+				t.Errorf("LoadJSON(%s) error = nil, want an error", tt.data)
+			}
+		})
+	}
+}
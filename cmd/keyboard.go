@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+)
+
+// keyboard delivers single key presses from the controlling terminal. While open, the
+// terminal is in cbreak mode: input is unbuffered and not echoed, but signals such as
+// Ctrl+C still work.
+type keyboard struct {
+	saved string    // terminal settings to restore, as reported by "stty -g"
+	keys  chan byte // key presses, in the order they were typed
+}
+
+// openKeyboard switches the terminal attached to standard input into cbreak mode and
+// starts a goroutine that forwards every key press to the returned keyboard.
+//
+// Returns:
+//   - A pointer to the opened keyboard.
+//   - An error if standard input is not a terminal or its mode cannot be changed.
+func openKeyboard() (*keyboard, error) {
+	if !isTerminal(os.Stdin) {
+		return nil, errors.New("keyboard: standard input is not a terminal")
+	}
+
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+
+	k := &keyboard{saved: strings.TrimSpace(saved), keys: make(chan byte, 16)}
+	go k.read()
+
+	return k, nil
+}
+
+// read forwards bytes from standard input to the keys channel until reading fails.
+func (k *keyboard) read() {
+	buf := make([]byte, 1)
+	for {
+		if n, err := os.Stdin.Read(buf); err != nil || n == 0 {
+			return
+		}
+		k.keys <- buf[0]
+	}
+}
+
+// Keys returns the channel of key presses. A nil keyboard returns a nil channel, which
+// never delivers a key.
+func (k *keyboard) Keys() <-chan byte {
+	if k == nil {
+		return nil
+	}
+	return k.keys
+}
+
+// Close restores the terminal settings saved by openKeyboard. Closing a nil keyboard is
+// a no-op.
+//
+// Returns:
+//   - An error if the terminal settings cannot be restored.
+func (k *keyboard) Close() error {
+	if k == nil {
+		return nil
+	}
+	_, err := stty(k.saved)
+	return err
+}
+
+// stty runs the stty command against the terminal attached to standard input.
+//
+// Parameters:
+//   - args: The stty arguments.
+//
+// Returns:
+//   - The command's standard output.
+//   - An error if the command fails.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("keyboard: stty %s: %w", strings.Join(args, " "), err)
+	}
+	return string(out), nil
+}
+
+// isTerminal reports whether f is a character device such as a terminal, rather than a
+// pipe or a regular file.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// terminalSize returns the size of the terminal attached to standard input.
+//
+// Returns:
+//   - The number of rows and columns.
+//   - An error if standard input is not a terminal or its size cannot be read.
+func terminalSize() (rows, cols int, err error) {
+	out, err := stty("size")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := fmt.Sscan(out, &rows, &cols); err != nil {
+		return 0, 0, fmt.Errorf("keyboard: stty size: unexpected output %q", strings.TrimSpace(out))
+	}
+	return rows, cols, nil
+}
+
+// watchTerminalSize reads the terminal size once and again every time the terminal is
+// resized, so that it can be asked for every frame without running stty each time.
+//
+// Parameters:
+//   - read: Reads the current size, such as terminalSize.
+//
+// Returns:
+//   - size: Returns the size most recently read.
+//   - stop: Stops watching for resizes.
+func watchTerminalSize(read func() (rows, cols int, err error)) (size func() (rows, cols int, err error), stop func()) {
+	var mu sync.Mutex
+	rows, cols, err := read()
+
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-resized:
+				r, c, e := read()
+				mu.Lock()
+				rows, cols, err = r, c, e
+				mu.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	size = func() (int, int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return rows, cols, err
+	}
+	stop = func() {
+		signal.Stop(resized)
+		close(done)
+	}
+	return size, stop
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+// TestWatchTerminalSize checks that the watched size is read once up front and served
+// from then on without reading it again.
+func TestWatchTerminalSize(t *testing.T) {
+	reads := 0
+	size, stop := watchTerminalSize(func() (int, int, error) {
+		reads++
+		return 24, 80, nil
+	})
+	defer stop()
+
+	for range 3 {
+		if rows, cols, err := size(); rows != 24 || cols != 80 || err != nil {
+			t.Fatalf("size() = %d, %d, %v, want 24, 80, nil", rows, cols, err)
+		}
+	}
+	if reads != 1 {
+		t.Errorf("the size was read %d times for three frames, want 1", reads)
+	}
+}
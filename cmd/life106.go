@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// life106Header is the mandatory first line of a Life 1.06 file.
+const life106Header = "#Life 1.06"
+
+// LoadLife106 reads a pattern in the Life 1.06 format and returns a Game with the
+// pattern centered on a grid of at least defaultGridSize x defaultGridSize, enlarged to
+// fit the pattern.
+//
+// The input starts with the "#Life 1.06" header followed by whitespace-separated
+// "x y" integer pairs, one live cell per pair, where x is the column and y is the row.
+// Coordinates may be negative; they are normalized by the pattern's bounding box.
+//
+// Parameters:
+//   - r: The reader providing the Life 1.06 data.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error on a missing header, malformed coordinates, or coordinates spanning more
+//     than maxPatternSize cells along an axis.
+func LoadLife106(r io.Reader) (*Game, error) {
+	scanner := bufio.NewScanner(r)
+	var coords [][2]int
+	headerFound := false
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !headerFound {
+			if line != life106Header {
+				return nil, fmt.Errorf("life106: expected %q header, got %q", life106Header, line)
+			}
+			headerFound = true
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("life106: line %d: expected \"x y\", got %q", lineNo, line)
+		}
+		x, errX := strconv.Atoi(fields[0])
+		y, errY := strconv.Atoi(fields[1])
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("life106: line %d: invalid coordinates %q", lineNo, line)
+		}
+		coords = append(coords, [2]int{y, x})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("life106: %w", err)
+	}
+	if !headerFound {
+		return nil, fmt.Errorf("life106: missing %q header", life106Header)
+	}
+
+	if len(coords) == 0 {
+		return newEmptyGame(defaultGridSize, defaultGridSize), nil
+	}
+
+	minRow, minCol, maxRow, maxCol := coords[0][0], coords[0][1], coords[0][0], coords[0][1]
+	for _, c := range coords[1:] {
+		minRow, maxRow = min(minRow, c[0]), max(maxRow, c[0])
+		minCol, maxCol = min(minCol, c[1]), max(maxCol, c[1])
+	}
+
+	// The spans are taken as unsigned differences, which are exact for any max >= min, so
+	// coordinates near the ends of the int range cannot overflow into a small span.
+	if uint(maxCol)-uint(minCol) >= maxPatternSize || uint(maxRow)-uint(minRow) >= maxPatternSize {
+		return nil, fmt.Errorf("life106: coordinates spanning x %d..%d, y %d..%d exceed %d cells per axis",
+			minCol, maxCol, minRow, maxRow, maxPatternSize)
+	}
+	width, height := maxCol-minCol+1, maxRow-minRow+1
+
+	cells := make([][2]int, len(coords))
+	for i, c := range coords {
+		cells[i] = [2]int{c[0] - minRow, c[1] - minCol}
+	}
+	g := newEmptyGame(max(width, defaultGridSize), max(height, defaultGridSize))
+	if err := placeCentered(g, width, height, cells); err != nil {
+		return nil, fmt.Errorf("life106: coordinates spanning x %d..%d, y %d..%d: %w",
+			minCol, maxCol, minRow, maxRow, err)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestLoadLife106 loads patterns with negative coordinates and checks the grid they are
+// placed on.
+func TestLoadLife106(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		width, height int
+		live          int
+		wantErr       bool
+	}{
+		{"glider", "#Life 1.06\n0 -1\n1 0\n-1 1\n0 1\n1 1\n", defaultGridSize, defaultGridSize, 5, false},
+		{"wider than the default grid", "#Life 1.06\n-20 0\n19 0\n", 40, defaultGridSize, 2, false},
+		{"empty", "#Life 1.06\n", defaultGridSize, defaultGridSize, 0, false},
+		{"missing header", "0 0\n", 0, 0, 0, true},
+		{"too far apart", "#Life 1.06\n0 0\n100000 0\n", 0, 0, 0, true},
+		{"far from the origin", "#Life 1.06\n1000000000 -1000000000\n1000000001 -1000000000\n", defaultGridSize, defaultGridSize, 2, false},
+		{"spanning the int range", "#Life 1.06\n-9223372036854775808 0\n9223372036854775807 0\n", 0, 0, 0, true},
+		{"spanning the int range by rows", "#Life 1.06\n0 9223372036854775807\n0 -9223372036854775808\n", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := LoadLife106(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("LoadLife106() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadLife106() error = %v", err)
+			}
+			if g.grid.Width() != tt.width || g.grid.Height() != tt.height {
+				t.Errorf("grid is %dx%d, want %dx%d", g.grid.Width(), g.grid.Height(), tt.width, tt.height)
+			}
+			if got := g.CountLiveCells(); got != tt.live {
+				t.Errorf("CountLiveCells() = %d, want %d", got, tt.live)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxPatternSize bounds the width and height of a pattern read from a file, so that a
+// header or a few far apart coordinates cannot allocate an enormous grid.
+const maxPatternSize = 1 << 14
+
+// placeCentered sets a pattern's live cells on the game grid so that the pattern's
+// bounding box is centered on the grid.
+//
+// Parameters:
+//   - g: The game whose grid receives the pattern.
+//   - width: The number of columns spanned by the pattern.
+//   - height: The number of rows spanned by the pattern.
+//   - cells: The live cells as [row, column] pairs relative to the pattern's top-left corner.
+//
+// Returns:
+//   - An error if the pattern does not fit on the grid.
+func placeCentered(g *Game, width, height int, cells [][2]int) error {
+	gridWidth, gridHeight := g.grid.Width(), g.grid.Height()
+	if width > gridWidth || height > gridHeight {
+		return fmt.Errorf("pattern of %dx%d does not fit on a %dx%d grid", width, height, gridWidth, gridHeight)
+	}
+	ox, oy := (gridHeight-height)/2, (gridWidth-width)/2
+
+	for _, c := range cells {
+		g.grid[ox+c[0]][oy+c[1]] = true
+	}
+
+	return nil
+}
+
+// centerOn moves the game onto a new grid of the given dimensions with the bounding box
+// of its live cells centered, keeping their ages and heat map counts.
+//
+// Parameters:
+//   - g: The game to move.
+//   - width: The number of columns of the new grid.
+//   - height: The number of rows of the new grid.
+//
+// Returns:
+//   - An error if the live cells do not fit on the new grid; the game is left unchanged.
+func centerOn(g *Game, width, height int) error {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		g.reframe(width, height, 0, 0)
+		return nil
+	}
+
+	boxWidth, boxHeight := maxY-minY+1, maxX-minX+1
+	if boxWidth > width || boxHeight > height {
+		return fmt.Errorf("pattern of %dx%d does not fit on a %dx%d grid", boxWidth, boxHeight, width, height)
+	}
+	g.reframe(width, height, minX-(height-boxHeight)/2, minY-(width-boxWidth)/2)
+
+	return nil
+}
+
+// patternFormats lists the names accepted by loadPatternFile's format argument.
+var patternFormats = []string{"rle", "cells", "life106", "json", "gol"}
+
+// loadPatternFile opens the pattern file at path and decodes it into a Game. A path of
+// "-" reads the pattern from standard input instead.
+//
+// The format is one of patternFormats. When it is empty it is chosen from the file
+// extension (".rle", ".cells" for plaintext, ".lif" or ".life" for Life 1.06, ".json" or
+// ".gol" for a saved game snapshot) or, for standard input and other extensions, sniffed
+// from the content by sniffFormat.
+//
+// Parameters:
+//   - path: The pattern file path, or "-" for standard input.
+//   - format: The pattern format, or "" to detect it.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error if the format is unknown or the file cannot be opened or decoded.
+func loadPatternFile(path, format string) (*Game, error) {
+	if format != "" && !slices.Contains(patternFormats, format) {
+		return nil, fmt.Errorf("unknown format %q (want %s)", format, strings.Join(patternFormats, ", "))
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "" && path != "-" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".rle":
+			format = "rle"
+		case ".cells":
+			format = "cells"
+		case ".lif", ".life":
+			format = "life106"
+		case ".json":
+			format = "json"
+		case ".gol":
+			format = "gol"
+		}
+	}
+	if format == "" {
+		format = sniffFormat(data)
+	}
+
+	r := bytes.NewReader(data)
+	switch format {
+	case "cells":
+		return LoadCells(r)
+	case "life106":
+		return LoadLife106(r)
+	case "json":
+		return LoadJSON(r)
+	case "gol":
+		return LoadBinary(r)
+	default:
+		return LoadRLE(r)
+	}
+}
+
+// sniffFormat guesses the format of a pattern from its first meaningful line: a
+// "#Life 1.06" header, a '!' comment of a plaintext file, an "x = ..." RLE header (after
+// any '#' comment lines), a '{' opening a JSON snapshot, or rows of '.', 'O' and '*'
+// cells. Binary snapshots are recognized by their magic. Anything else is treated as RLE.
+//
+// Parameters:
+//   - data: The pattern file contents.
+//
+// Returns:
+//   - One of patternFormats.
+func sniffFormat(data []byte) string {
+	if bytes.HasPrefix(data, []byte(binaryMagic)) {
+		return "gol"
+	}
+
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#Life 1.06"):
+			return "life106"
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "!"):
+			return "cells"
+		case strings.HasPrefix(line, "{"):
+			return "json"
+		case strings.HasPrefix(line, "x"):
+			return "rle"
+		case strings.Trim(line, ".O*") == "":
+			return "cells"
+		default:
+			return "rle"
+		}
+	}
+	return "rle"
+}
+
+// savePatternFile writes the game to path, as a full JSON snapshot when the path has a
+// ".json" extension, as a binary snapshot for ".gol", as plaintext for ".cells", as a PNG
+// image for ".png" and as an RLE pattern of the live cells otherwise.
+//
+// Parameters:
+//   - g: The game to save.
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func savePatternFile(g *Game, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.NewEncoder(f).Encode(g)
+	case ".gol":
+		var data []byte
+		if data, err = g.MarshalBinary(); err == nil {
+			_, err = f.Write(data)
+		}
+	case ".cells":
+		err = g.WriteCells(f)
+	case ".png":
+		err = g.WritePNG(f, defaultCellSize)
+	default:
+		err = g.WriteRLE(f)
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
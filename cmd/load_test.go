@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestLoadPatternFileFormats feeds a blinker through every pattern format, from a file
+// whose format is sniffed and from standard input with the format given, and checks that
+// each loads the same three cells.
+func TestLoadPatternFileFormats(t *testing.T) {
+	blinker := NewGame(WithSize(5, 5), WithPatternAt("blinker", 2, 1))
+	snapshotJSON, err := blinker.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotGOL, err := blinker.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format string
+		data   []byte
+	}{
+		{"rle", []byte("#N Blinker\nx = 3, y = 1, rule = B3/S23\n3o!\n")},
+		{"cells", []byte("!Name: Blinker\nOOO\n")},
+		{"life106", []byte("#Life 1.06\n0 0\n1 0\n2 0\n")},
+		{"json", snapshotJSON},
+		{"gol", snapshotGOL},
+	}
+
+	want := [][2]int{{0, 0}, {0, 1}, {0, 2}}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := sniffFormat(tt.data); got != tt.format {
+				t.Errorf("sniffFormat() = %q, want %q", got, tt.format)
+			}
+
+			path := filepath.Join(t.TempDir(), "blinker")
+			if err := os.WriteFile(path, tt.data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			g, err := loadPatternFile(path, "")
+			if err != nil {
+				t.Fatalf("loadPatternFile(%q, \"\") error = %v", path, err)
+			}
+			if got := boxCells(g); !slices.Equal(got, want) {
+				t.Errorf("sniffed %s pattern has cells %v, want %v", tt.format, got, want)
+			}
+
+			stdin, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer stdin.Close()
+			defer func(f *os.File) { os.Stdin = f }(os.Stdin)
+			os.Stdin = stdin
+			g, err = loadPatternFile("-", tt.format)
+			if err != nil {
+				t.Fatalf("loadPatternFile(\"-\", %q) error = %v", tt.format, err)
+			}
+			if got := boxCells(g); !slices.Equal(got, want) {
+				t.Errorf("%s pattern from standard input has cells %v, want %v", tt.format, got, want)
+			}
+		})
+	}
+
+	if _, err := loadPatternFile("-", "mcell"); err == nil {
+		t.Error("loadPatternFile with format mcell returned no error")
+	}
+}
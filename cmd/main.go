@@ -1,109 +1,783 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"math/bits"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 const (
-	// gridSize defines the width and height of the game grid.
-	gridSize = 25
+	// defaultGridSize defines the default width and height of the game grid.
+	defaultGridSize = 25
 
-	// liveCell is the character displayed for live cells.
+	// minGridSize is the smallest width or height that fits the glider placed at the grid center.
+	minGridSize = 5
+
+	// liveCell is the default character displayed for live cells.
 	liveCell = "X"
 
-	// deadCell is the character displayed for dead cells.
+	// deadCell is the default character displayed for dead cells.
 	deadCell = "."
 
-	// delay is the duration between generation updates.
+	// ghostCell is the character displayed for recently dead cells with the default glyphs.
+	ghostCell = "x"
+
+	// delay is the default duration between generation updates.
 	delay = 200 * time.Millisecond
 
-	// generations specifies the total number of generations to simulate.
+	// minDelay and maxDelay are the default bounds of the delay adjusted at runtime with
+	// the + and - keys, see RunOptions.
+	minDelay = 10 * time.Millisecond
+	maxDelay = 2 * time.Second
+
+	// generations is the default number of generations to simulate.
 	generations = 1_000
 )
 
-// Grid represents the game's universe as a 2-dimensional boolean array.
+// Grid represents the game's universe as a 2-dimensional boolean slice indexed as [row][column].
 // true indicates a live cell, false indicates a dead cell.
-type Grid [gridSize][gridSize]bool
+type Grid [][]bool
 
-// Game encapsulates the current state and generation count for Conway's Game of Life.
+// Game encapsulates the current state, generation count and rule for Conway's Game of Life
+// and other Life-like cellular automata.
 type Game struct {
-	grid Grid
-	gen  int
+	grid         Grid
+	gen          int
+	rule         Rule
+	neighborhood Neighborhood
+	boundaryX    Boundary // resolves row indices beyond the top and bottom edges
+	boundaryY    Boundary // resolves column indices beyond the left and right edges
+
+	// populationHistory holds the live-cell count of every generation simulated so far,
+	// starting with the initial state. It is filled in lazily by NextGen.
+	populationHistory []int
+
+	// initial is the grid before the first NextGen, bit-packed, and initialGen its
+	// generation, restored by Reset. It is nil until the first NextGen and never
+	// modified, so clones share it.
+	initial    *BitGrid
+	initialGen int
+
+	// spare is the grid of the previous generation, which NextGen overwrites with the next
+	// generation instead of allocating a new grid. It is never shared with a Clone.
+	spare Grid
+
+	// bands holds the outcome of the bands of the previous NextGen, whose changed lists
+	// are reused by the next one. Like spare, it is never shared with a Clone.
+	bands []band
+
+	// packed holds the grid bit-packed for NextGen, which computes packedNext from it and
+	// then swaps the two. packed matches grid whenever active is non-nil, since code that
+	// writes to grid directly resets active, and Set and Toggle update both. Like spare,
+	// neither is ever shared with a Clone.
+	packed     *BitGrid
+	packedNext *BitGrid
+
+	// maxPopulation is the largest entry of populationHistory and maxPopulationGen the
+	// generation it was first reached at.
+	maxPopulation    int
+	maxPopulationGen int
+
+	// changeHistory holds the births and deaths of every NextGen recorded in
+	// populationHistory, so changeHistory[i] led to populationHistory[i+1].
+	changeHistory [][2]int
+
+	// lastBirths and lastDeaths count the cells that came alive and died in the most recent NextGen.
+	lastBirths int
+	lastDeaths int
+
+	// ages counts, per cell, the consecutive generations a live cell has survived. It is
+	// allocated by the first NextGen; a nil slice means every cell has age 0.
+	ages [][]int
+
+	// colorAges makes Render colorize live cells by age with ANSI 256-color codes.
+	colorAges bool
+
+	// liveGlyph and deadGlyph are drawn by Render for live and dead cells; empty strings
+	// select liveCell and deadCell.
+	liveGlyph string
+	deadGlyph string
+
+	// plain makes the renderers avoid ANSI escape codes, for output that is not a terminal.
+	plain bool
+
+	// follow makes the renderers center the live cells in the frame.
+	follow bool
+
+	// ghostSteps is the number of frames Render keeps showing a cell that died as a ghost.
+	ghostSteps int
+
+	// diedAt holds, per cell, the generation in which the cell last died, or 0 if it has
+	// not died. It is allocated by NextGen while ghostSteps is positive.
+	diedAt [][]int
+
+	// undo remembers the states replaced by recent NextGen calls for Undo. It is nil
+	// unless EnableUndo was called.
+	undo *undoBuffer
+
+	// heat counts, per cell, the generations in which the cell changed state. It is nil
+	// unless EnableHeatmap was called, in which case NextGen keeps it up to date.
+	heat [][]int
+
+	// active marks the rows NextGen has to recompute: the rows holding cells that changed
+	// in the previous generation or were edited with Set or Toggle, and the rows next to
+	// them. Every cell of another row keeps its state. A nil slice means every row is
+	// computed, so code that writes to grid directly must reset it to nil.
+	active []bool
+
+	// workers is the number of goroutines NextGen computes the grid with, each handling a
+	// band of rows; 0 means one per CPU.
+	workers int
+}
+
+// newGrid allocates a Grid of the given dimensions with all cells dead.
+//
+// Parameters:
+//   - width: The number of columns in the grid.
+//   - height: The number of rows in the grid.
+//
+// Returns:
+//   - A Grid with height rows of width cells each.
+func newGrid(width, height int) Grid {
+	grid := make(Grid, height)
+	for x := range grid {
+		grid[x] = make([]bool, width)
+	}
+	return grid
+}
+
+// Width returns the number of columns in the grid.
+func (gr Grid) Width() int {
+	if len(gr) == 0 {
+		return 0
+	}
+	return len(gr[0])
+}
+
+// Height returns the number of rows in the grid.
+func (gr Grid) Height() int {
+	return len(gr)
+}
+
+// Clone returns a deep copy of the grid that shares no storage with the original.
+func (gr Grid) Clone() Grid {
+	c := newGrid(gr.Width(), gr.Height())
+	for x := range gr {
+		copy(c[x], gr[x])
+	}
+	return c
+}
+
+// Equal reports whether two grids have the same dimensions and identical cell states.
+//
+// Parameters:
+//   - other: The grid to compare against.
+//
+// Returns:
+//   - true if every cell matches, false otherwise.
+func (gr Grid) Equal(other Grid) bool {
+	if len(gr) != len(other) {
+		return false
+	}
+
+	for x := range gr {
+		if len(gr[x]) != len(other[x]) {
+			return false
+		}
+		for y := range gr[x] {
+			if gr[x][y] != other[x][y] {
+				return false
+			}
+		}
+	}
+
+	return true
 }
 
-// NewGame creates and initializes a new Game instance with a predefined glider pattern
-// positioned near the center of the grid.
+// newEmptyGame creates a Game of the given dimensions with every cell dead, using ConwayRule.
+//
+// Parameters:
+//   - width: The number of columns in the grid.
+//   - height: The number of rows in the grid.
 //
 // Returns:
 //   - A pointer to the initialized Game struct.
-func NewGame() *Game {
-	g := &Game{grid: Grid{}}
-	center := gridSize / 2
+func newEmptyGame(width, height int) *Game {
+	return &Game{grid: newGrid(width, height), rule: ConwayRule}
+}
 
-	g.grid[center][center+1] = true
-	g.grid[center+1][center+2] = true
-	g.grid[center+2][center] = true
-	g.grid[center+2][center+1] = true
-	g.grid[center+2][center+2] = true
+// NewGameFromGrid creates a Game sized to a 2-dimensional slice indexed as [row][column]
+// and copies its cells, using ConwayRule on a toroidal grid. The slice is not retained.
+//
+// Parameters:
+//   - cells: The initial cell states; true is a live cell.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error if the slice has no cells or its rows differ in length.
+func NewGameFromGrid(cells [][]bool) (*Game, error) {
+	if len(cells) == 0 || len(cells[0]) == 0 {
+		return nil, fmt.Errorf("grid has no cells")
+	}
+	for x, row := range cells {
+		if len(row) != len(cells[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, want %d", x, len(row), len(cells[0]))
+		}
+	}
 
-	return g
+	return &Game{grid: Grid(cells).Clone(), rule: ConwayRule}, nil
+}
+
+// NewGameFromString creates a Game from text art with one line per row, such as a raw
+// string literal, using ConwayRule on a toroidal grid. Every occurrence of the live rune
+// is a live cell and every other character a dead one. The grid is as wide as the longest
+// line; shorter lines are padded with dead cells. A single line break at the start and
+// end of the art is ignored, and so are carriage returns.
+//
+// Parameters:
+//   - art: The rows of the grid, separated by line breaks.
+//   - live: The rune that marks a live cell, e.g. 'X'.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error if the art has no cells.
+func NewGameFromString(art string, live rune) (*Game, error) {
+	art = strings.ReplaceAll(art, "\r", "")
+	art = strings.TrimPrefix(strings.TrimSuffix(art, "\n"), "\n")
+	lines := strings.Split(art, "\n")
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+	if width == 0 {
+		return nil, fmt.Errorf("text art has no cells")
+	}
+
+	g := newEmptyGame(width, len(lines))
+	for x, line := range lines {
+		for y, r := range []rune(line) {
+			g.grid[x][y] = r == live
+		}
+	}
+	return g, nil
 }
 
 // ClearScreen clears the terminal screen using ANSI escape codes.
 //
 // Note: Compatible with most modern terminals.
 func ClearScreen() {
-	fmt.Print("\033[2J\033[H")
+	fmt.Print(clearScreen)
 }
 
-// LiveNeighbors calculates the number of live neighbors around a specific cell.
-// It uses toroidal wrapping at grid edges.
+// LiveNeighbors calculates the number of live neighbors around a specific cell using the
+// game's neighborhood (Moore by default, or VonNeumann which skips the diagonals).
+// Neighbors beyond the grid edges are resolved by the game's boundary: Toroidal wraps
+// around to the opposite edge, Dead counts them as dead and Mirror reflects them back onto
+// the nearest in-bounds cell.
 //
 // Parameters:
 //   - x: The X-coordinate (row index) of the target cell.
 //   - y: The Y-coordinate (column index) of the target cell.
 //
 // Returns:
-//   - The count of live neighboring cells (0-8).
+//   - The count of live neighboring cells (0-8 for Moore, 0-4 for VonNeumann).
 func (g *Game) LiveNeighbors(x, y int) int {
 	count := 0
 
-	for i := -1; i <= 1; i++ {
-		for j := -1; j <= 1; j++ {
-			if i == 0 && j == 0 {
-				continue
-			}
-			nx, ny := (x+i+gridSize)%gridSize, (y+j+gridSize)%gridSize
-			if g.grid[nx][ny] {
-				count++
-			}
+	for _, d := range neighborOffsets[g.neighborhood] {
+		if g.Get(x+d[0], y+d[1]) {
+			count++
 		}
 	}
 
 	return count
 }
 
-// NextGen computes the next generation by applying the rules of Conway's Game of Life
-// to each cell, updating the game's internal grid state.
+// cell resolves a possibly out-of-range coordinate to a grid position using the game's
+// boundary, the same way LiveNeighbors looks up neighbors.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//
+// Returns:
+//   - The in-range row and column.
+//   - false if the coordinate lies beyond a Dead boundary.
+func (g *Game) cell(x, y int) (int, int, bool) {
+	rx, okX := g.boundaryX.resolve(x, g.grid.Height())
+	ry, okY := g.boundaryY.resolve(y, g.grid.Width())
+	return rx, ry, okX && okY
+}
+
+// SetBoundary changes how all four grid edges behave, taking effect on the next NextGen.
 //
-// Rules applied:
-//   - Any live cell with 2 or 3 neighbors survives.
-//   - Any dead cell with exactly 3 neighbors becomes alive.
+// Parameters:
+//   - b: The new boundary.
+func (g *Game) SetBoundary(b Boundary) {
+	g.SetBoundaries(b, b)
+}
+
+// SetBoundaries sets the boundary of each axis separately, taking effect on the next
+// NextGen. For example, SetBoundaries(Dead, Toroidal) makes a cylinder that wraps from
+// the right edge to the left edge but not from the bottom edge to the top.
+//
+// Parameters:
+//   - x: The boundary of the row axis, beyond the top and bottom edges.
+//   - y: The boundary of the column axis, beyond the left and right edges.
+func (g *Game) SetBoundaries(x, y Boundary) {
+	g.boundaryX, g.boundaryY = x, y
+	g.active = nil
+}
+
+// Get reports whether the cell at the given coordinate is alive. Coordinates outside the
+// grid are resolved by the game's boundary: they wrap on a Toroidal grid, reflect on a
+// Mirror grid and always read as dead on a Dead grid.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//
+// Returns:
+//   - true if the cell is alive.
+func (g *Game) Get(x, y int) bool {
+	rx, ry, ok := g.cell(x, y)
+	return ok && g.grid[rx][ry]
+}
+
+// Set makes the cell at the given coordinate alive or dead. Coordinates are resolved as
+// in Get; setting a cell beyond a Dead boundary has no effect.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//   - alive: The new state of the cell.
+func (g *Game) Set(x, y int, alive bool) {
+	if rx, ry, ok := g.cell(x, y); ok {
+		g.grid[rx][ry] = alive
+		g.resetAge(rx, ry)
+		g.activate(rx)
+		g.repack(rx, ry)
+	}
+}
+
+// Toggle flips the state of the cell at the given coordinate. Coordinates are resolved
+// as in Get; toggling a cell beyond a Dead boundary has no effect.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+func (g *Game) Toggle(x, y int) {
+	if rx, ry, ok := g.cell(x, y); ok {
+		g.grid[rx][ry] = !g.grid[rx][ry]
+		g.resetAge(rx, ry)
+		g.activate(rx)
+		g.repack(rx, ry)
+	}
+}
+
+// Age returns the number of consecutive generations the cell at the given in-range
+// coordinate has survived, 0 for a newborn or dead cell.
+//
+// Parameters:
+//   - x: The row index.
+//   - y: The column index.
+//
+// Returns:
+//   - The age of the cell.
+func (g *Game) Age(x, y int) int {
+	if g.ages == nil {
+		return 0
+	}
+	return g.ages[x][y]
+}
+
+// repack copies an in-range cell of the grid into the bit-packed copy, if it is current.
+func (g *Game) repack(x, y int) {
+	if g.active != nil && g.packed != nil {
+		g.packed.Set(x, y, g.grid[x][y])
+	}
+}
+
+// resetAge sets the age of an in-range cell back to 0.
+func (g *Game) resetAge(x, y int) {
+	if g.ages != nil {
+		g.ages[x][y] = 0
+	}
+}
+
+// Clone returns a deep copy of the game, including its grid, generation, rule, neighborhood,
+// boundary and statistics. Advancing or editing the clone leaves the original untouched.
+func (g *Game) Clone() *Game {
+	c := *g
+	c.grid = g.grid.Clone()
+	c.spare, c.bands = nil, nil
+	c.packed, c.packedNext = nil, nil
+	c.populationHistory = slices.Clone(g.populationHistory)
+	c.changeHistory = slices.Clone(g.changeHistory)
+	if g.ages != nil {
+		c.ages = make([][]int, len(g.ages))
+		for x := range g.ages {
+			c.ages[x] = slices.Clone(g.ages[x])
+		}
+	}
+	if g.diedAt != nil {
+		c.diedAt = make([][]int, len(g.diedAt))
+		for x := range g.diedAt {
+			c.diedAt[x] = slices.Clone(g.diedAt[x])
+		}
+	}
+	if g.undo != nil {
+		c.undo = &undoBuffer{snapshots: slices.Clone(g.undo.snapshots), limit: g.undo.limit}
+	}
+	if g.heat != nil {
+		c.heat = make([][]int, len(g.heat))
+		for x := range g.heat {
+			c.heat[x] = slices.Clone(g.heat[x])
+		}
+	}
+	c.active = slices.Clone(g.active)
+	return &c
+}
+
+// Equal reports whether two games have grids of the same dimensions with identical cell
+// states. The generation, rule and other settings are not compared.
+//
+// Parameters:
+//   - other: The game to compare against.
+//
+// Returns:
+//   - true if every cell matches, false otherwise or if other is nil.
+func (g *Game) Equal(other *Game) bool {
+	return other != nil && g.grid.Equal(other.grid)
+}
+
+// Clear kills every cell on the grid without reallocating it. The generation count and
+// statistics are left untouched; use Reset to start over from the initial state.
+func (g *Game) Clear() {
+	for x := range g.grid {
+		clear(g.grid[x])
+	}
+	for x := range g.ages {
+		clear(g.ages[x])
+	}
+	g.diedAt = nil
+	g.active = nil
+}
+
+// Reset returns the game to its initial state, the grid and generation it had before the
+// first NextGen, discarding the population history, the birth/death counts of the last
+// step, the undo history and the heat map counts. A game that has not been stepped is
+// already in its initial state and keeps its cells.
+func (g *Game) Reset() {
+	if g.initial != nil {
+		if g.initial.Width() != g.grid.Width() || g.initial.Height() != g.grid.Height() {
+			g.reframe(g.initial.Width(), g.initial.Height(), 0, 0)
+		}
+		g.initial.copyTo(g.grid)
+		g.gen = g.initialGen
+	}
+	g.diedAt, g.active = nil, nil
+	g.populationHistory, g.changeHistory = nil, nil
+	g.lastBirths, g.lastDeaths = 0, 0
+	g.ages = nil
+	if g.undo != nil {
+		g.undo.snapshots = nil
+	}
+	for x := range g.heat {
+		clear(g.heat[x])
+	}
+}
+
+// NextGen computes the next generation by applying the game's rule to each cell,
+// updating the game's internal grid state.
+//
+// Rules applied (B3/S23 by default):
+//   - Any live cell whose neighbor count is in the rule's survival set survives.
+//   - Any dead cell whose neighbor count is in the rule's birth set becomes alive.
 //   - All other cells die or remain dead.
+//
+// The live-cell count of the new generation is appended to the population history and the
+// number of births and deaths in the step is recorded. The age of every surviving cell is
+// incremented and the age of every other cell is reset to 0.
+//
+// The cells are computed 64 at a time on a bit-packed copy of the grid, as in
+// BitGrid.NextGen. Only the active rows, those holding cells that changed in the previous
+// generation or were edited since and the rows next to them, are recomputed; no cell of
+// another row or its neighboring rows changed, so its cells cannot change either.
+//
+// The rows are split into bands computed concurrently by the game's workers; the result
+// is identical to computing them one after another.
+//
+// The new generation is written into the grids of the previous one rather than freshly
+// allocated grids, and the per-band buffers are kept from one call to the next, so once
+// the grid size settles a single-worker NextGen allocates nothing but the occasional
+// growth of the population history.
 func (g *Game) NextGen() {
-	var newGrid Grid
+	g.remember()
+	if g.initial == nil {
+		g.initial, g.initialGen = BitGridFrom(g.grid), g.gen
+	}
+	if len(g.populationHistory) == 0 {
+		g.populationHistory = append(g.populationHistory, g.CountLiveCells())
+		g.maxPopulation, g.maxPopulationGen = g.populationHistory[0], g.gen
+	}
+	if g.ages == nil {
+		g.ages = make([][]int, g.grid.Height())
+		for x := range g.ages {
+			g.ages[x] = make([]int, g.grid.Width())
+		}
+	}
 
-	for x := 0; x < gridSize; x++ {
-		for y := 0; y < gridSize; y++ {
-			neighbors := g.LiveNeighbors(x, y)
-			newGrid[x][y] = neighbors == 3 || (g.grid[x][y] && neighbors == 2)
+	// The two grids take turns: the current one becomes the spare that receives the
+	// generation after next. stepRows writes every cell of next, so nothing of the old
+	// contents survives. The packed grids take turns the same way.
+	next := g.spare
+	if next.Width() != g.grid.Width() || next.Height() != g.grid.Height() {
+		next = newGrid(g.grid.Width(), g.grid.Height())
+	}
+	if g.packed == nil || g.packed.Width() != next.Width() || g.packed.Height() != next.Height() {
+		g.packed, g.packedNext = NewBitGrid(next.Width(), next.Height()), NewBitGrid(next.Width(), next.Height())
+		g.active = nil
+	}
+	if g.active == nil {
+		g.packed.pack(g.grid)
+	}
+	population, births, deaths := 0, 0, 0
+
+	// Each worker fills a band of rows in next, packedNext and ages, reading only the
+	// current grids, so the bands can be computed concurrently without locking. A single
+	// band is computed on the calling goroutine.
+	workers := min(g.workerCount(), next.Height())
+	if len(g.bands) != workers {
+		g.bands = make([]band, workers)
+	}
+	bands := g.bands
+	if workers == 1 {
+		bands[0] = g.stepRows(next, 0, next.Height(), bands[0])
+	} else {
+		g.stepBands(next)
+	}
+
+	active := g.active
+	if active == nil {
+		active = make([]bool, next.Height())
+	} else {
+		clear(active)
+	}
+	g.active = active
+	if g.ghostSteps > 0 && g.diedAt == nil {
+		g.diedAt = make([][]int, next.Height())
+		for x := range g.diedAt {
+			g.diedAt[x] = make([]int, next.Width())
+		}
+	}
+
+	for _, b := range bands {
+		population += b.population
+		births += b.births
+		deaths += b.deaths
+		for _, c := range b.changed {
+			g.activate(c[0])
+			if g.heat != nil {
+				g.heat[c[0]][c[1]]++
+			}
+			if g.diedAt != nil && !next[c[0]][c[1]] {
+				g.diedAt[c[0]][c[1]] = g.gen + 1
+			}
 		}
 	}
 
-	g.grid = newGrid
+	g.grid, g.spare = next, g.grid
+	g.packed, g.packedNext = g.packedNext, g.packed
 	g.gen++
+	g.populationHistory = append(g.populationHistory, population)
+	g.changeHistory = append(g.changeHistory, [2]int{births, deaths})
+	if population > g.maxPopulation {
+		g.maxPopulation, g.maxPopulationGen = population, g.gen
+	}
+	g.lastBirths, g.lastDeaths = births, deaths
+}
+
+// Step advances the game by n generations, exactly as n calls to NextGen would. The
+// generations are written alternately into the game's two grid buffers, so no grid is
+// allocated along the way.
+//
+// Parameters:
+//   - n: The number of generations to advance; zero or a negative count does nothing.
+func (g *Game) Step(n int) {
+	for range max(n, 0) {
+		g.NextGen()
+	}
+}
+
+// stepBands computes the next generation into next like stepRows, split into one band of
+// rows per entry of g.bands, each computed on its own goroutine.
+//
+// Parameters:
+//   - next: The grid receiving the next generation.
+func (g *Game) stepBands(next Grid) {
+	size := (next.Height() + len(g.bands) - 1) / len(g.bands)
+
+	var wg sync.WaitGroup
+	for i := range g.bands {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			from, to := i*size, min((i+1)*size, next.Height())
+			g.bands[i] = g.stepRows(next, from, to, g.bands[i])
+		}()
+	}
+	wg.Wait()
+}
+
+// band holds the outcome of computing a band of rows in NextGen.
+type band struct {
+	population int
+	births     int
+	deaths     int
+
+	// changed lists the cells of the band, as [row, column] pairs, that were born or died.
+	changed [][2]int
+
+	// scratch holds the words BitGrid.stepRows works in, kept for the next generation.
+	scratch []uint64
+}
+
+// stepRows computes rows [from, to) of the next generation into next and packedNext and
+// updates the ages of those rows. Runs of active rows are computed with the word-parallel
+// kernel; the other rows cannot change and are copied as they are. Only the cells that
+// changed or survived are then visited, to update next and the ages.
+//
+// Parameters:
+//   - next: The grid receiving the next generation.
+//   - from: The first row to compute.
+//   - to: The row after the last one to compute.
+//   - prev: The band's outcome of the previous generation, whose buffers are reused.
+//
+// Returns:
+//   - The population, births, deaths and changed cells within the rows.
+func (g *Game) stepRows(next Grid, from, to int, prev band) band {
+	b := band{changed: prev.changed[:0], scratch: prev.scratch}
+	if len(b.scratch) < bitScratchRows*g.packed.stride {
+		b.scratch = make([]uint64, bitScratchRows*g.packed.stride)
+	}
+
+	for x := from; x < to; {
+		end := x + 1
+		if g.active != nil && !g.active[x] {
+			copy(g.packedNext.row(x), g.packed.row(x))
+		} else {
+			for end < to && (g.active == nil || g.active[end]) {
+				end++
+			}
+			g.packed.stepRows(g.packedNext, x, end, g.rule, g.neighborhood, g.boundaryX, g.boundaryY, b.scratch)
+		}
+
+		for ; x < end; x++ {
+			old, now := g.packed.row(x), g.packedNext.row(x)
+			copy(next[x], g.grid[x])
+			for i := range now {
+				b.population += bits.OnesCount64(now[i])
+				for survived := old[i] & now[i]; survived != 0; survived &= survived - 1 {
+					g.ages[x][i*64+bits.TrailingZeros64(survived)]++
+				}
+				for changed := old[i] ^ now[i]; changed != 0; changed &= changed - 1 {
+					y := i*64 + bits.TrailingZeros64(changed)
+					alive := now[i]&(1<<(y%64)) != 0
+					next[x][y] = alive
+					g.ages[x][y] = 0
+					b.changed = append(b.changed, [2]int{x, y})
+					if alive {
+						b.births++
+					} else {
+						b.deaths++
+					}
+				}
+			}
+		}
+	}
+
+	return b
+}
+
+// activate adds the row of a changed cell and the rows next to it, whose cells count it as
+// a neighbor, to the active set, so the next NextGen recomputes them. It has no effect
+// while the active set is nil.
+//
+// Parameters:
+//   - x: The in-range row index of the cell.
+func (g *Game) activate(x int) {
+	if g.active == nil {
+		return
+	}
+	for d := -1; d <= 1; d++ {
+		if rx, ok := g.boundaryX.resolve(x+d, g.grid.Height()); ok {
+			g.active[rx] = true
+		}
+	}
+}
+
+// workerCount returns the number of goroutines NextGen splits the grid across.
+func (g *Game) workerCount() int {
+	if g.workers > 0 {
+		return g.workers
+	}
+	return runtime.NumCPU()
+}
+
+// Generation returns the number of generations simulated since the initial state.
+func (g *Game) Generation() int {
+	return g.gen
+}
+
+// LastBirths returns the number of dead cells that came alive in the most recent NextGen.
+func (g *Game) LastBirths() int {
+	return g.lastBirths
+}
+
+// LastDeaths returns the number of live cells that died in the most recent NextGen.
+func (g *Game) LastDeaths() int {
+	return g.lastDeaths
+}
+
+// PopulationHistory returns the live-cell count of every generation simulated so far,
+// starting with the initial state, so its length is the number of generations plus one.
+// For a game restored with LoadJSON the history starts at the restored generation.
+//
+// Returns:
+//   - A copy of the population history that the caller may modify freely.
+func (g *Game) PopulationHistory() []int {
+	if len(g.populationHistory) == 0 {
+		return []int{g.CountLiveCells()}
+	}
+	return slices.Clone(g.populationHistory)
+}
+
+// MaxPopulation returns the largest live-cell count in the population history and the
+// generation at which it was first reached.
+//
+// Returns:
+//   - population: The peak population, the current one before the first NextGen.
+//   - gen: The generation of the peak.
+func (g *Game) MaxPopulation() (population, gen int) {
+	if len(g.populationHistory) == 0 {
+		return g.CountLiveCells(), g.gen
+	}
+	return g.maxPopulation, g.maxPopulationGen
 }
 
 // CountLiveCells counts the total number of currently live cells on the grid.
@@ -124,49 +798,473 @@ func (g *Game) CountLiveCells() int {
 	return count
 }
 
-// Print outputs the current state of the grid to the terminal with clear visual borders.
-func (g *Game) Print() {
-	border := strings.Repeat("─", gridSize+2)
-	fmt.Println("┌" + border + "┐")
+// ForEachLive calls fn with the row and column of every live cell, in row-major order.
+//
+// Parameters:
+//   - fn: The callback invoked for each live cell.
+func (g *Game) ForEachLive(fn func(x, y int)) {
+	for x, row := range g.grid {
+		for y, alive := range row {
+			if alive {
+				fn(x, y)
+			}
+		}
+	}
+}
 
-	for _, row := range g.grid {
-		fmt.Print("│ ")
-		for _, cell := range row {
-			if cell {
-				fmt.Print(liveCell)
-			} else {
-				fmt.Print(deadCell)
+// LiveCells returns the [row, column] coordinates of every live cell, sorted in row-major
+// order, so the same grid always yields the same list.
+//
+// Returns:
+//   - The live cells, nil if there are none.
+func (g *Game) LiveCells() [][2]int {
+	var cells [][2]int
+	g.ForEachLive(func(x, y int) {
+		cells = append(cells, [2]int{x, y})
+	})
+	return cells
+}
+
+// SetLiveCells replaces the grid's cells with the given live cells; every other cell dies
+// and all cell ages restart. Coordinates are resolved as in Set: on a Toroidal grid they
+// wrap around, and beyond any other boundary they are ignored.
+//
+// Parameters:
+//   - cells: The live cells as [row, column] pairs, e.g. as returned by LiveCells.
+func (g *Game) SetLiveCells(cells [][2]int) {
+	g.Clear()
+	for _, c := range cells {
+		g.Set(c[0], c[1], true)
+	}
+}
+
+// BoundingBox finds the smallest rectangle containing every live cell in a single scan of
+// the grid.
+//
+// Returns:
+//   - The top-left (minX, minY) and bottom-right (maxX, maxY) corners as row and column indices.
+//   - false if the grid has no live cells.
+func (g *Game) BoundingBox() (minX, minY, maxX, maxY int, ok bool) {
+	for x, row := range g.grid {
+		for y, cell := range row {
+			if !cell {
+				continue
+			}
+			if !ok {
+				minX, minY, maxX, maxY, ok = x, y, x, y, true
+				continue
 			}
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
 		}
-		fmt.Println(" │")
 	}
 
-	fmt.Println("└" + border + "┘")
+	return minX, minY, maxX, maxY, ok
 }
 
-// main starts and runs the simulation for a predefined number of generations.
-// It initializes the game, updates the grid state, and prints each generation.
+// parseCoords parses a "row,column" coordinate pair such as "12,7".
+//
+// Parameters:
+//   - s: The coordinate string.
+//
+// Returns:
+//   - The row and column.
+//   - An error if s is not two comma-separated integers.
+func parseCoords(s string) (x, y int, err error) {
+	xs, ys, ok := strings.Cut(s, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected row,column, got %q", s)
+	}
+	if x, err = strconv.Atoi(strings.TrimSpace(xs)); err != nil {
+		return 0, 0, fmt.Errorf("invalid row %q", xs)
+	}
+	if y, err = strconv.Atoi(strings.TrimSpace(ys)); err != nil {
+		return 0, 0, fmt.Errorf("invalid column %q", ys)
+	}
+	return x, y, nil
+}
+
+// main starts and runs the simulation for the number of generations given by -generations.
+// It parses the command-line flags, initializes the game, updates the grid state,
+// and prints each generation.
 //
 // Simulation can be stopped manually by pressing Ctrl+C.
 func main() {
-	game := NewGame()
+	width := flag.Int("width", defaultGridSize, "number of columns in the grid")
+	height := flag.Int("height", defaultGridSize, "number of rows in the grid")
+	file := flag.String("file", "", "load the initial pattern from an RLE, .cells, .lif, .json or .gol file (\"-\" for standard input)")
+	format := flag.String("format", "", "format of the -file pattern: "+strings.Join(patternFormats, ", ")+" (default: from the extension or content)")
+	pattern := flag.String("pattern", "", "start from a named pattern: "+strings.Join(patternNames(), ", "))
+	at := flag.String("at", "", "row,column of the -pattern's top-left corner (default: the grid center)")
+	random := flag.Bool("random", false, "start from a random grid instead of the glider (a -pattern is placed on top)")
+	density := flag.Float64("density", 0.3, "probability that a cell starts alive with -random")
+	seed := flag.Int64("seed", 0, "random seed for -random (default: derived from the current time)")
+	ruleFlag := flag.String("rule", "", "rule preset (life, highlife, daynight, seeds, replicator) "+
+		"or B/S notation such as B36/S23 (default: the pattern's rule or B3/S23)")
+	neighborhoodFlag := flag.String("neighborhood", "moore", "neighborhood to count: moore or vonneumann")
+	boundaryFlag := flag.String("boundary", "toroidal", "edge behavior: toroidal, dead or mirror, a rows,columns pair such as dead,toroidal, or cylinder")
+	generationsFlag := flag.Int("generations", generations, "number of generations to simulate (0 to run until a stopping condition or Ctrl+C)")
+	delayFlag := flag.Duration("delay", delay, "initial time between generations, e.g. 100ms")
+	stopEmpty := flag.Bool("stop-empty", true, "stop as soon as every cell has died")
+	stopStable := flag.Bool("stop-stable", false, "stop as soon as the grid stops changing")
+	detectCycle := flag.Bool("detect-cycle", false, "stop when a previous generation repeats and report the period")
+	cycleHistory := flag.Int("cycle-history", 1_000, "number of past generations remembered by -detect-cycle")
+	quiet := flag.Bool("quiet", false, "print generations one after another without clearing the screen or using ANSI colors")
+	forceColor := flag.Bool("force-color", false, "clear the screen and use ANSI colors even when standard output is not a terminal")
+	summary := flag.Bool("summary", false, "print a report of the run (populations and how it ended) to standard error")
+	batch := flag.Int("batch", 0, "analyze this many random grids from consecutive seeds, starting at -seed, and report their outcomes")
+	headless := flag.Bool("headless", false, "simulate without drawing or delays and print only a summary of the run")
+	analyze := flag.Bool("analyze", false, "run without drawing until the pattern dies out, settles or repeats, then report the outcome")
+	renderFlag := flag.String("render", "text", "grid rendering: text, braille (2x4 cells per character) or halfblock (1x2 cells per character)")
+	incremental := flag.Bool("incremental", false, "redraw only the cells that changed since the previous frame (-render text only)")
+	ghost := flag.Int("ghost", 0, "keep showing cells that died for this many frames with a dimmer glyph (-render text only)")
+	liveFlag := flag.String("live", liveCell, "character drawn for live cells by -render text")
+	deadFlag := flag.String("dead", deadCell, "character drawn for dead cells by -render text (as wide as -live)")
+	colorFlag := flag.Bool("color", false, "colorize live cells by age (bright when young, dim when old)")
+	follow := flag.Bool("follow", false, "keep the live cells centered in the view as they move")
+	gifPath := flag.String("gif", "", "record the run as an animated GIF at this path")
+	gifFrames := flag.Int("gif-frames", 0, "maximum number of frames recorded by -gif (0 for every generation)")
+	gifDelay := flag.Duration("gif-delay", delay, "time each frame is shown in the -gif animation")
+	record := flag.String("record", "", "record every generation of the run to this file for playback with -replay")
+	replay := flag.String("replay", "", "play back a run recorded with -record instead of simulating")
+	step := flag.Bool("step", false, "start paused and advance one generation per Enter key press")
+	workers := flag.Int("workers", 0, "number of goroutines computing each generation, or analyzing runs with -batch (0 for one per CPU)")
+	csvPath := flag.String("csv", "", "write the population, births and deaths of every generation to this CSV file")
+	heatmap := flag.String("heatmap", "", "write a PNG heat map of how often each cell changed during the run to this path")
+	undo := flag.Int("undo", 100, "number of generations the u key can step back in an interactive run")
+	httpAddr := flag.String("http", "", "also serve the run to web browsers at this address, e.g. :8080")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile of the simulation to this file (see go tool pprof)")
+	memProfile := flag.String("memprofile", "", "write a heap profile to this file when the simulation ends (see go tool pprof)")
+	save := flag.String("save", "", "write the final generation to an RLE file, a full JSON snapshot for a .json path, plaintext for a .cells path or an image for a .png path")
+	flag.Parse()
 
-	for i := 0; i < generations; i++ {
-		ClearScreen()
+	if *generationsFlag < 0 || *delayFlag < 0 {
+		fmt.Fprintf(os.Stderr, "-generations and -delay must not be negative\n")
+		os.Exit(2)
+	}
 
-		fmt.Printf("Conway's Game of Life - Generation: %d | Live Cells: %d\n",
-			game.gen, game.CountLiveCells())
+	if *headless && *step {
+		fmt.Fprintf(os.Stderr, "-headless cannot be combined with -step\n")
+		os.Exit(2)
+	}
 
-		game.Print()
-		fmt.Println("Press Ctrl+C to exit")
+	if *replay != "" {
+		snapshots, err := loadRecording(*replay)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loading recording: %v\n", err)
+			os.Exit(1)
+		}
+		if err := Replay(snapshots, os.Stdout, *delayFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *width < minGridSize || *height < minGridSize {
+		fmt.Fprintf(os.Stderr, "width and height must be at least %d\n", minGridSize)
+		os.Exit(2)
+	}
+
+	if *file != "" && (*random || *pattern != "") {
+		fmt.Fprintf(os.Stderr, "-file cannot be combined with -random or -pattern\n")
+		os.Exit(2)
+	}
+
+	rule := ConwayRule
+	if *ruleFlag != "" {
+		var err error
+		if rule, err = resolveRule(*ruleFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	neighborhood, err := ParseNeighborhood(*neighborhoodFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
 
-		game.NextGen()
-		time.Sleep(delay)
+	boundaryX, boundaryY, err := ParseBoundaries(*boundaryFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
 	}
 
-	// Final state display
-	ClearScreen()
-	fmt.Printf("Conway's Game of Life - Final Generation: %d | Live Cells: %d\n",
-		game.gen, game.CountLiveCells())
-	game.Print()
+	// Settings stored in a loaded file are only overridden by flags given explicitly.
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	if (*random || *batch > 0) && !explicit["seed"] {
+		*seed = time.Now().UnixNano()
+	}
+
+	if *batch > 0 {
+		results, err := BatchRun(*batch, BatchConfig{
+			Options: []Option{
+				WithSize(*width, *height),
+				WithRule(rule),
+				WithNeighborhood(neighborhood),
+				WithBoundaries(boundaryX, boundaryY),
+			},
+			Workers:        *workers,
+			Density:        *density,
+			Seed:           *seed,
+			MaxGenerations: *generationsFlag,
+			History:        *cycleHistory,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+		if err := writeBatchReport(os.Stdout, results); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var game *Game
+	if *file != "" {
+		if game, err = loadPatternFile(*file, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "loading pattern: %v\n", err)
+			os.Exit(1)
+		}
+		if explicit["rule"] {
+			game.rule = rule
+		}
+		if explicit["neighborhood"] {
+			game.neighborhood = neighborhood
+		}
+		if explicit["boundary"] {
+			game.SetBoundaries(boundaryX, boundaryY)
+		}
+		game.workers = *workers
+
+		// The pattern is centered on a grid of the requested size, keeping the loaded
+		// size along an axis whose flag was not given.
+		if explicit["width"] || explicit["height"] {
+			w, h := game.grid.Width(), game.grid.Height()
+			if explicit["width"] {
+				w = *width
+			}
+			if explicit["height"] {
+				h = *height
+			}
+			if err := centerOn(game, w, h); err != nil {
+				fmt.Fprintf(os.Stderr, "loading pattern: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	} else {
+		options := []Option{
+			WithSize(*width, *height),
+			WithRule(rule),
+			WithNeighborhood(neighborhood),
+			WithBoundaries(boundaryX, boundaryY),
+			WithWorkers(*workers),
+		}
+		if *random {
+			options = append(options, WithRandom(*density, *seed))
+		}
+		switch {
+		case *pattern != "" && *at != "":
+			x, y, err := parseCoords(*at)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "-at: %v\n", err)
+				os.Exit(2)
+			}
+			options = append(options, WithPatternAt(*pattern, x, y))
+		case *pattern != "":
+			options = append(options, WithPattern(*pattern))
+		}
+
+		if game, err = BuildGame(options...); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// Output that is not a terminal, such as a pipe or a file, gets no ANSI escape codes
+	// unless -force-color asks for them.
+	plain := *quiet || (!isTerminal(os.Stdout) && !*forceColor)
+	if err := game.SetGlyphs(*liveFlag, *deadFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+	game.colorAges = *colorFlag
+	game.SetGhostSteps(*ghost)
+	if *heatmap != "" {
+		game.EnableHeatmap()
+	}
+	game.plain = plain
+	game.follow = *follow
+
+	// Profiling covers the simulation only, not loading the pattern or saving results.
+	stopCPUProfile := func() error { return nil }
+	if *cpuProfile != "" {
+		if stopCPUProfile, err = startCPUProfile(*cpuProfile); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+	stopProfiling := func() {
+		if err := stopCPUProfile(); err != nil {
+			fmt.Fprintf(os.Stderr, "writing cpu profile: %v\n", err)
+			os.Exit(1)
+		}
+		if *memProfile != "" {
+			if err := writeHeapProfile(*memProfile); err != nil {
+				fmt.Fprintf(os.Stderr, "writing heap profile: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	if *analyze {
+		fmt.Println(game.Analyze(*generationsFlag, *cycleHistory))
+		stopProfiling()
+		if *random {
+			fmt.Printf("Random seed: %d\n", *seed)
+		}
+		if *save != "" {
+			if err := savePatternFile(game, *save); err != nil {
+				fmt.Fprintf(os.Stderr, "saving pattern: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	opts := RunOptions{
+		Generations:  *generationsFlag,
+		Delay:        *delayFlag,
+		StopEmpty:    *stopEmpty,
+		StopStable:   *stopStable,
+		DetectCycle:  *detectCycle,
+		CycleHistory: *cycleHistory,
+		Paused:       *step,
+		Quiet:        plain,
+		Headless:     *headless,
+		Incremental:  *incremental,
+	}
+	if *summary {
+		opts.Summary = os.Stderr
+	}
+	switch *renderFlag {
+	case "text":
+	case "braille":
+		opts.Render = (*Game).RenderBraille
+	case "halfblock":
+		opts.Render = (*Game).RenderHalfBlock
+	default:
+		fmt.Fprintf(os.Stderr, "unknown -render %q (want text, braille or halfblock)\n", *renderFlag)
+		os.Exit(2)
+	}
+
+	// The first Ctrl+C cancels ctx so the run loop can finish normally and print the final
+	// summary; a second Ctrl+C restores the terminal and quits immediately. A failing HTTP
+	// server also cancels ctx, with its error as the cause.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	// The address is bound before the keyboard puts the terminal into raw mode, so a bad
+	// or busy -http address is reported with the terminal intact.
+	if *httpAddr != "" {
+		ln, err := net.Listen("tcp", *httpAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "http: %v\n", err)
+			os.Exit(1)
+		}
+		srv := newServer()
+		srv.publish(game)
+		opts.OnGeneration = srv.publish
+		go func() {
+			cancel(fmt.Errorf("http: %w", http.Serve(ln, srv.handler())))
+		}()
+	}
+	if *gifPath != "" {
+		opts.recorder = newGIFRecorder(defaultCellSize, *gifDelay, *gifFrames)
+	}
+	if *record != "" {
+		opts.Record = new([]Grid)
+	}
+
+	// Keyboard controls are only available when standard input is a terminal, and are not
+	// used by headless runs.
+	var kb *keyboard
+	if !*headless {
+		if kb, err = openKeyboard(); err != nil {
+			if *step {
+				fmt.Fprintf(os.Stderr, "-step needs an interactive terminal: %v\n", err)
+				os.Exit(2)
+			}
+			kb = nil
+		}
+	}
+	opts.Keys = kb.Keys()
+	if kb != nil {
+		game.EnableUndo(*undo)
+	}
+
+	interrupt := make(chan os.Signal, 2)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		<-interrupt
+		cancel(nil)
+		<-interrupt
+		kb.Close()
+		os.Exit(130)
+	}()
+
+	err = game.Run(ctx, os.Stdout, opts)
+	kb.Close()
+	stopProfiling()
+	if errors.Is(err, context.Canceled) {
+		err = context.Cause(ctx)
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if *random {
+		fmt.Printf("Random seed: %d\n", *seed)
+	}
+
+	if opts.recorder != nil {
+		if err := opts.recorder.save(*gifPath); err != nil {
+			fmt.Fprintf(os.Stderr, "saving gif: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if opts.Record != nil {
+		if err := saveRecording(*record, *opts.Record); err != nil {
+			fmt.Fprintf(os.Stderr, "saving recording: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *csvPath != "" {
+		if err := savePopulationCSV(game, *csvPath); err != nil {
+			fmt.Fprintf(os.Stderr, "saving csv: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *heatmap != "" {
+		if err := saveHeatmap(game, *heatmap); err != nil {
+			fmt.Fprintf(os.Stderr, "saving heatmap: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *save != "" {
+		if err := savePatternFile(game, *save); err != nil {
+			fmt.Fprintf(os.Stderr, "saving pattern: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
@@ -0,0 +1,767 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestLiveNeighborsWrapsRectangularGrid checks that a 10x40 toroidal grid wraps each
+// axis at its own length.
+func TestLiveNeighborsWrapsRectangularGrid(t *testing.T) {
+	tests := []struct {
+		name     string
+		live     [][2]int
+		x, y     int
+		expected int
+	}{
+		{"rows wrap bottom to top", [][2]int{{9, 20}}, 0, 20, 1},
+		{"columns wrap right to left", [][2]int{{5, 39}}, 5, 0, 1},
+		{"corner wraps both axes", [][2]int{{9, 39}, {9, 0}, {0, 39}}, 0, 0, 3},
+		{"no wrap at row length", [][2]int{{9, 20}}, 0, 30, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newEmptyGame(40, 10)
+			for _, c := range tt.live {
+				g.grid[c[0]][c[1]] = true
+			}
+			if got := g.LiveNeighbors(tt.x, tt.y); got != tt.expected {
+				t.Errorf("LiveNeighbors(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNextGenWrapsRectangularGrid steps a blinker straddling the corner of a 10x40 grid,
+// which only oscillates if both axes wrap.
+func TestNextGenWrapsRectangularGrid(t *testing.T) {
+	g := newEmptyGame(40, 10)
+	for _, y := range []int{39, 0, 1} {
+		g.grid[0][y] = true
+	}
+
+	g.NextGen()
+	for _, x := range []int{9, 0, 1} {
+		if !g.grid[x][0] {
+			t.Errorf("cell (%d, 0) is dead after one generation, want alive", x)
+		}
+	}
+	if got := g.CountLiveCells(); got != 3 {
+		t.Errorf("CountLiveCells() = %d after one generation, want 3", got)
+	}
+
+	g.NextGen()
+	for _, y := range []int{39, 0, 1} {
+		if !g.grid[0][y] {
+			t.Errorf("cell (0, %d) is dead after two generations, want alive", y)
+		}
+	}
+}
+
+// TestGamesAreIndependent steps two games of different sizes, rules, boundaries and glyphs
+// side by side and checks that each one follows only its own configuration.
+func TestGamesAreIndependent(t *testing.T) {
+	small := NewGame(WithSize(12, 8), WithBoundary(Dead), WithRandom(0.4, 1))
+	large := NewGame(WithSize(50, 30), WithRule(mustParseRule("B36/S23")), WithRandom(0.4, 2))
+	if err := small.SetGlyphs("#", "."); err != nil {
+		t.Fatal(err)
+	}
+
+	// The large game runs two generations for each of the small one's.
+	for range 20 {
+		want := referenceNextGen(small)
+		small.NextGen()
+		if !small.grid.Equal(want) {
+			t.Fatalf("12x8 game differs from its reference at generation %d", small.Generation())
+		}
+		for range 2 {
+			want := referenceNextGen(large)
+			large.NextGen()
+			if !large.grid.Equal(want) {
+				t.Fatalf("50x30 game differs from its reference at generation %d", large.Generation())
+			}
+		}
+	}
+	if small.Generation() != 20 || large.Generation() != 40 {
+		t.Errorf("generations are %d and %d, want 20 and 40", small.Generation(), large.Generation())
+	}
+	if small.grid.Width() != 12 || small.grid.Height() != 8 || large.grid.Width() != 50 || large.grid.Height() != 30 {
+		t.Errorf("sizes are %dx%d and %dx%d, want 12x8 and 50x30", small.grid.Width(), small.grid.Height(),
+			large.grid.Width(), large.grid.Height())
+	}
+
+	var smallOut, largeOut strings.Builder
+	if err := small.Render(&smallOut); err != nil {
+		t.Fatal(err)
+	}
+	if err := large.Render(&largeOut); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(smallOut.String(), "#") || strings.Contains(largeOut.String(), "#") {
+		t.Error("the glyphs set on the 12x8 game are not used by it alone")
+	}
+}
+
+// TestNextGenWorkersMatchSerial checks that splitting NextGen across workers yields the
+// same grids, ages and birth and death counts as computing every row on one goroutine,
+// including worker counts that do not divide the grid height.
+func TestNextGenWorkersMatchSerial(t *testing.T) {
+	for _, workers := range []int{2, 3, 7, 64} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			options := []Option{WithSize(41, 37), WithBoundaries(Mirror, Toroidal), WithRandom(0.4, 3)}
+			serial := NewGame(append(options, WithWorkers(1))...)
+			parallel := NewGame(append(options, WithWorkers(workers))...)
+
+			for range 60 {
+				serial.NextGen()
+				parallel.NextGen()
+				if !parallel.Equal(serial) {
+					t.Fatalf("grids differ at generation %d", serial.Generation())
+				}
+				if parallel.LastBirths() != serial.LastBirths() || parallel.LastDeaths() != serial.LastDeaths() {
+					t.Fatalf("births/deaths %d/%d, want %d/%d at generation %d", parallel.LastBirths(),
+						parallel.LastDeaths(), serial.LastBirths(), serial.LastDeaths(), serial.Generation())
+				}
+				for x := range serial.ages {
+					if !slices.Equal(parallel.ages[x], serial.ages[x]) {
+						t.Fatalf("ages of row %d differ at generation %d", x, serial.Generation())
+					}
+				}
+			}
+		})
+	}
+}
+
+// referenceNextGen computes the generation after g's current one by evaluating every cell
+// with LiveNeighbors, without the active set or any other shortcut of NextGen.
+func referenceNextGen(g *Game) Grid {
+	next := newGrid(g.grid.Width(), g.grid.Height())
+	for x := range next {
+		for y := range next[x] {
+			next[x][y] = g.rule.Next(g.grid[x][y], g.LiveNeighbors(x, y))
+		}
+	}
+	return next
+}
+
+// TestNextGenActiveSetMatchesFullScan checks that re-evaluating only the active cells
+// gives the same generations as evaluating every cell, for every neighborhood and pair of
+// boundaries, including after cells are edited and the boundaries change mid-run.
+func TestNextGenActiveSetMatchesFullScan(t *testing.T) {
+	boundaries := []Boundary{Toroidal, Dead, Mirror}
+	for _, nh := range []Neighborhood{Moore, VonNeumann} {
+		for _, bx := range boundaries {
+			for _, by := range boundaries {
+				t.Run(fmt.Sprintf("%s %s", nh, formatBoundaries(bx, by)), func(t *testing.T) {
+					rule := ConwayRule
+					if nh == VonNeumann {
+						rule = mustParseRule("B2/S012")
+					}
+					g := NewGame(WithSize(23, 17), WithRule(rule), WithNeighborhood(nh),
+						WithBoundaries(bx, by), WithRandom(0.3, 11), WithWorkers(1))
+
+					for i := range 60 {
+						switch i {
+						case 20:
+							g.Toggle(0, 0)
+							g.Set(16, 22, true)
+						case 40:
+							g.SetBoundaries(by, bx)
+						}
+						want := referenceNextGen(g)
+						g.NextGen()
+						if !g.grid.Equal(want) {
+							t.Fatalf("generation %d differs from a full scan", g.Generation())
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestPopulationHistory checks that the history holds the initial population and one
+// count per generation, and that changing the returned copy leaves the game's history
+// alone.
+func TestPopulationHistory(t *testing.T) {
+	g := NewGame(WithSize(20, 20), WithRandom(0.3, 8))
+	want := []int{g.CountLiveCells()}
+
+	for n := range 10 {
+		if got := g.PopulationHistory(); !slices.Equal(got, want) {
+			t.Fatalf("PopulationHistory() after %d generations = %v, want %v", n, got, want)
+		}
+		g.NextGen()
+		want = append(want, g.CountLiveCells())
+	}
+
+	history := g.PopulationHistory()
+	history[0] = -1
+	if got := g.PopulationHistory(); got[0] == -1 || len(got) != 11 {
+		t.Errorf("PopulationHistory() = %v after changing a returned copy", got)
+	}
+}
+
+// TestBirthsAndDeaths steps a blinker, which gains its two new end cells and loses its two
+// old ones in every phase, and checks the counts recorded for each step.
+func TestBirthsAndDeaths(t *testing.T) {
+	g := NewGame(WithSize(5, 5), WithPatternAt("blinker", 1, 1))
+	if g.LastBirths() != 0 || g.LastDeaths() != 0 {
+		t.Errorf("births/deaths before the first step = %d/%d, want 0/0", g.LastBirths(), g.LastDeaths())
+	}
+
+	for range 4 {
+		g.NextGen()
+		if g.LastBirths() != 2 || g.LastDeaths() != 2 {
+			t.Errorf("births/deaths at generation %d = %d/%d, want 2/2", g.Generation(), g.LastBirths(), g.LastDeaths())
+		}
+	}
+}
+
+// TestCellAccessors checks that Set, Get and Toggle address the same cell in range, wrap
+// coordinates beyond a toroidal edge and ignore coordinates beyond a dead one.
+func TestCellAccessors(t *testing.T) {
+	tests := []struct {
+		name     string
+		boundary Boundary
+		x, y     int
+		cell     [2]int
+		inRange  bool
+	}{
+		{"in range", Toroidal, 3, 4, [2]int{3, 4}, true},
+		{"wrapping past the far corner", Toroidal, 8, 13, [2]int{0, 1}, true},
+		{"wrapping negative coordinates", Toroidal, -1, -2, [2]int{7, 10}, true},
+		{"in range on a dead grid", Dead, 7, 11, [2]int{7, 11}, true},
+		{"beyond a dead edge", Dead, 8, 0, [2]int{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(12, 8), WithBoundary(tt.boundary), WithRandom(0, 1))
+			want := func(alive bool) [][2]int {
+				if alive && tt.inRange {
+					return [][2]int{tt.cell}
+				}
+				return nil
+			}
+
+			for i, step := range []struct {
+				edit  func()
+				alive bool
+			}{
+				{func() { g.Set(tt.x, tt.y, true) }, true},
+				{func() { g.Toggle(tt.x, tt.y) }, false},
+				{func() { g.Toggle(tt.x, tt.y) }, true},
+				{func() { g.Set(tt.x, tt.y, false) }, false},
+			} {
+				step.edit()
+				if got := g.Get(tt.x, tt.y); got != (step.alive && tt.inRange) {
+					t.Errorf("edit %d: Get(%d, %d) = %v, want %v", i, tt.x, tt.y, got, step.alive && tt.inRange)
+				}
+				if got := g.LiveCells(); !slices.Equal(got, want(step.alive)) {
+					t.Errorf("edit %d: live cells = %v, want %v", i, got, want(step.alive))
+				}
+			}
+		})
+	}
+}
+
+// TestClear checks that Clear kills every cell and leaves the generation count alone.
+func TestClear(t *testing.T) {
+	g := NewGame(WithSize(30, 20), WithRandom(0.5, 3))
+	g.Step(3)
+	g.Clear()
+
+	if got := g.CountLiveCells(); got != 0 {
+		t.Errorf("CountLiveCells() after Clear = %d, want 0", got)
+	}
+	if got := g.Generation(); got != 3 {
+		t.Errorf("Generation() after Clear = %d, want 3", got)
+	}
+	g.NextGen()
+	if got := g.CountLiveCells(); got != 0 {
+		t.Errorf("CountLiveCells() a generation after Clear = %d, want 0", got)
+	}
+}
+
+// TestClone advances and edits a clone and checks that the original keeps its grid,
+// generation and population history.
+func TestClone(t *testing.T) {
+	g := NewGame(WithSize(20, 20), WithRandom(0.35, 4))
+	g.Step(2)
+	want, history := g.grid.Clone(), g.PopulationHistory()
+
+	c := g.Clone()
+	if !c.Equal(g) || c.Generation() != g.Generation() || c.rule != g.rule {
+		t.Fatal("the clone differs from the original before it is stepped")
+	}
+	c.Step(5)
+	c.Toggle(0, 0)
+
+	if !g.grid.Equal(want) {
+		t.Error("stepping the clone changed the original grid")
+	}
+	if g.Generation() != 2 || !slices.Equal(g.PopulationHistory(), history) {
+		t.Errorf("original at generation %d with history %v, want 2 and %v", g.Generation(),
+			g.PopulationHistory(), history)
+	}
+	if c.Generation() != 7 {
+		t.Errorf("clone at generation %d, want 7", c.Generation())
+	}
+}
+
+// TestEqual compares games with identical grids, grids that differ in one cell and grids
+// of different sizes.
+func TestEqual(t *testing.T) {
+	base := NewGame(WithSize(10, 8), WithRandom(0.4, 5))
+	oneCell := base.Clone()
+	oneCell.Toggle(7, 9)
+	sameCellsWider := NewGame(WithSize(11, 8), WithRandom(0, 1))
+	base.ForEachLive(func(x, y int) { sameCellsWider.Set(x, y, true) })
+
+	tests := []struct {
+		name     string
+		other    *Game
+		expected bool
+	}{
+		{"identical", NewGame(WithSize(10, 8), WithRandom(0.4, 5)), true},
+		{"one cell differs", oneCell, false},
+		{"different sizes", sameCellsWider, false},
+		{"transposed size", NewGame(WithSize(8, 10), WithRandom(0.4, 5)), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Equal(tt.other); got != tt.expected {
+				t.Errorf("Equal() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBoundingBox checks the extents of the live cells of an empty grid, a single cell and
+// a diagonal pair.
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name                   string
+		live                   [][2]int
+		minX, minY, maxX, maxY int
+		ok                     bool
+	}{
+		{"empty", nil, 0, 0, 0, 0, false},
+		{"single cell", [][2]int{{4, 7}}, 4, 7, 4, 7, true},
+		{"diagonal pair", [][2]int{{2, 9}, {6, 1}}, 2, 1, 6, 9, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newEmptyGame(12, 8)
+			for _, c := range tt.live {
+				g.grid[c[0]][c[1]] = true
+			}
+			minX, minY, maxX, maxY, ok := g.BoundingBox()
+			if ok != tt.ok || (ok && (minX != tt.minX || minY != tt.minY || maxX != tt.maxX || maxY != tt.maxY)) {
+				t.Errorf("BoundingBox() = %d, %d, %d, %d, %v, want %d, %d, %d, %d, %v", minX, minY, maxX, maxY, ok,
+					tt.minX, tt.minY, tt.maxX, tt.maxY, tt.ok)
+			}
+		})
+	}
+}
+
+// TestNewGameFromGrid builds a vertical blinker from a literal, steps it and checks that
+// it turns horizontal, that the literal is not retained and that ragged or empty literals
+// are refused.
+func TestNewGameFromGrid(t *testing.T) {
+	cells := [][]bool{
+		{false, false, false, false, false},
+		{false, false, true, false, false},
+		{false, false, true, false, false},
+		{false, false, true, false, false},
+		{false, false, false, false, false},
+	}
+	g, err := NewGameFromGrid(cells)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells[0][0] = true
+
+	g.NextGen()
+	if want := [][2]int{{2, 1}, {2, 2}, {2, 3}}; !slices.Equal(g.LiveCells(), want) {
+		t.Errorf("blinker after one generation = %v, want %v", g.LiveCells(), want)
+	}
+
+	for _, bad := range [][][]bool{nil, {{}}, {{true, false}, {true}}} {
+		if _, err := NewGameFromGrid(bad); err == nil {
+			t.Errorf("NewGameFromGrid(%v) returned no error", bad)
+		}
+	}
+}
+
+// TestMarshalBinary checks the exact encoding of a small known game, then round-trips a
+// 128x128 random game through UnmarshalBinary and LoadBinary and checks that re-encoding
+// it yields the same bytes.
+func TestMarshalBinary(t *testing.T) {
+	small := newEmptyGame(3, 2)
+	small.grid[0][1], small.grid[1][2] = true, true
+	data, err := small.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{'G', 'o', 'L', binaryVersion, 3, 2, 0, 0x00, 0x08, 0x00, 0x0c, 0, 0, 0, 0x22}
+	if !bytes.Equal(data, want) {
+		t.Errorf("MarshalBinary() of a 3x2 game = % x, want % x", data, want)
+	}
+
+	g := NewGame(WithSize(128, 128), WithRule(mustParseRule("B36/S23")), WithNeighborhood(VonNeumann),
+		WithBoundaries(Mirror, Dead), WithRandom(0.5, 12))
+	g.Step(3)
+	data, err = g.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored Game
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+	loaded, err := LoadBinary(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadBinary() error = %v", err)
+	}
+	for name, r := range map[string]*Game{"UnmarshalBinary": &restored, "LoadBinary": loaded} {
+		if !r.Equal(g) || r.Generation() != 3 || r.rule != g.rule || r.neighborhood != VonNeumann ||
+			r.boundaryX != Mirror || r.boundaryY != Dead {
+			t.Errorf("%s restored a different game", name)
+		}
+		again, err := r.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, data) {
+			t.Errorf("re-encoding the game restored by %s changed its bytes", name)
+		}
+	}
+}
+
+// TestForEachLive collects the cells of a glider visited by ForEachLive on a dense and a
+// sparse universe and checks that both list exactly its cells in row-major order.
+func TestForEachLive(t *testing.T) {
+	sparse, err := NewSparseGame(ConwayRule, Moore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sparse.Place(patterns["glider"], 4, -3)
+
+	tests := []struct {
+		name     string
+		universe Universe
+		expected [][2]int
+	}{
+		{"dense", NewGame(WithSize(12, 10), WithPatternAt("glider", 4, 0)), [][2]int{{4, 1}, {5, 2}, {6, 0}, {6, 1}, {6, 2}}},
+		{"sparse", sparse, [][2]int{{4, -2}, {5, -1}, {6, -3}, {6, -2}, {6, -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+			tt.universe.ForEachLive(func(x, y int) { got = append(got, [2]int{x, y}) })
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("ForEachLive visited %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestReset runs a game for 50 generations, resets it and checks that it is back at its
+// initial cells and generation 0 with no history, and that it then retraces the same run.
+func TestReset(t *testing.T) {
+	g := NewGame(WithSize(30, 30), WithRandom(0.3, 13))
+	initial := g.LiveCells()
+	g.Step(50)
+	after50 := g.LiveCells()
+
+	g.Reset()
+	if !slices.Equal(g.LiveCells(), initial) {
+		t.Error("Reset() did not restore the initial cells")
+	}
+	if g.Generation() != 0 {
+		t.Errorf("Generation() after Reset = %d, want 0", g.Generation())
+	}
+	if history := g.PopulationHistory(); len(history) != 1 || history[0] != len(initial) {
+		t.Errorf("PopulationHistory() after Reset = %v, want [%d]", history, len(initial))
+	}
+	if g.LastBirths() != 0 || g.LastDeaths() != 0 {
+		t.Errorf("births/deaths after Reset = %d/%d, want 0/0", g.LastBirths(), g.LastDeaths())
+	}
+
+	g.Step(50)
+	if !slices.Equal(g.LiveCells(), after50) {
+		t.Error("the run after Reset() differs from the first one at generation 50")
+	}
+}
+
+// TestNewGameFromString builds a toad from text art with ragged lines, checks its cells
+// and that it oscillates with period 2, and checks that art without cells is refused.
+func TestNewGameFromString(t *testing.T) {
+	g, err := NewGameFromString(`
+......
+..
+..###.
+.###
+......
+`, '#')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.grid.Width() != 6 || g.grid.Height() != 5 {
+		t.Fatalf("grid is %dx%d, want 6x5", g.grid.Width(), g.grid.Height())
+	}
+	toad := [][2]int{{2, 2}, {2, 3}, {2, 4}, {3, 1}, {3, 2}, {3, 3}}
+	if !slices.Equal(g.LiveCells(), toad) {
+		t.Fatalf("live cells = %v, want %v", g.LiveCells(), toad)
+	}
+
+	g.NextGen()
+	if slices.Equal(g.LiveCells(), toad) || g.CountLiveCells() != 6 {
+		t.Errorf("toad after one generation = %v, want its other phase", g.LiveCells())
+	}
+	g.NextGen()
+	if !slices.Equal(g.LiveCells(), toad) {
+		t.Errorf("toad after two generations = %v, want %v", g.LiveCells(), toad)
+	}
+
+	for _, art := range []string{"", "\n", "\r\n"} {
+		if _, err := NewGameFromString(art, '#'); err == nil {
+			t.Errorf("NewGameFromString(%q) returned no error", art)
+		}
+	}
+}
+
+// TestLiveCells checks that LiveCells lists the cells in row-major order however they were
+// set, and that SetLiveCells round-trips them and wraps or drops out-of-range cells.
+func TestLiveCells(t *testing.T) {
+	cells := [][2]int{{0, 3}, {1, 0}, {1, 7}, {4, 2}, {5, 5}}
+	shuffled := [][2]int{{5, 5}, {1, 7}, {0, 3}, {4, 2}, {1, 0}}
+
+	g := NewGame(WithSize(8, 6), WithRandom(0, 1))
+	g.SetLiveCells(shuffled)
+	if got := g.LiveCells(); !slices.Equal(got, cells) {
+		t.Errorf("LiveCells() = %v, want %v", got, cells)
+	}
+	if got := g.LiveCells(); !slices.Equal(got, cells) {
+		t.Errorf("a second LiveCells() = %v, want %v", got, cells)
+	}
+
+	random := NewGame(WithSize(20, 15), WithRandom(0.4, 9))
+	copied := NewGame(WithSize(20, 15), WithRandom(0.9, 1))
+	copied.SetLiveCells(random.LiveCells())
+	if !copied.Equal(random) {
+		t.Error("SetLiveCells(LiveCells()) does not reproduce the grid")
+	}
+
+	g.SetLiveCells([][2]int{{6, 8}, {-1, 2}})
+	if want := [][2]int{{0, 0}, {5, 2}}; !slices.Equal(g.LiveCells(), want) {
+		t.Errorf("LiveCells() after setting wrapping cells = %v, want %v", g.LiveCells(), want)
+	}
+	g.SetBoundary(Dead)
+	g.SetLiveCells([][2]int{{6, 8}, {2, 2}})
+	if want := [][2]int{{2, 2}}; !slices.Equal(g.LiveCells(), want) {
+		t.Errorf("LiveCells() after setting a cell beyond a dead edge = %v, want %v", g.LiveCells(), want)
+	}
+	if g := NewGame(WithSize(4, 4), WithRandom(0, 1)); g.LiveCells() != nil {
+		t.Errorf("LiveCells() of an empty grid = %v, want nil", g.LiveCells())
+	}
+}
+
+// TestStep checks that Step(10) on one clone leaves it exactly where ten NextGen calls
+// leave another, and that a negative count does nothing.
+func TestStep(t *testing.T) {
+	g := NewGame(WithSize(40, 30), WithRandom(0.35, 14), WithBoundaries(Mirror, Toroidal))
+	stepped, looped := g.Clone(), g.Clone()
+
+	stepped.Step(10)
+	for range 10 {
+		looped.NextGen()
+	}
+	if !stepped.Equal(looped) || stepped.Generation() != 10 || looped.Generation() != 10 {
+		t.Errorf("Step(10) reached generation %d with different cells from ten NextGen calls at %d",
+			stepped.Generation(), looped.Generation())
+	}
+	if !slices.Equal(stepped.PopulationHistory(), looped.PopulationHistory()) {
+		t.Errorf("population history after Step(10) = %v, want %v", stepped.PopulationHistory(),
+			looped.PopulationHistory())
+	}
+
+	stepped.Step(-3)
+	if stepped.Generation() != 10 || !stepped.Equal(looped) {
+		t.Errorf("Step(-3) moved the game to generation %d", stepped.Generation())
+	}
+}
+
+// TestMaxPopulation checks the peak of patterns whose population is known every
+// generation: three cells of a block that fill in to the block in one generation and
+// then stay, and a pair of cells that dies at once.
+func TestMaxPopulation(t *testing.T) {
+	tests := []struct {
+		name             string
+		cells            [][2]int
+		population, peak int
+	}{
+		{"block from three cells", [][2]int{{3, 3}, {3, 4}, {4, 3}}, 4, 1},
+		{"dying pair", [][2]int{{3, 3}, {3, 4}}, 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(8, 8), WithRandom(0, 1))
+			g.SetLiveCells(tt.cells)
+			if population, gen := g.MaxPopulation(); population != len(tt.cells) || gen != 0 {
+				t.Errorf("MaxPopulation() before the first step = %d, %d, want %d, 0", population, gen, len(tt.cells))
+			}
+
+			g.Step(10)
+			if population, gen := g.MaxPopulation(); population != tt.population || gen != tt.peak {
+				t.Errorf("MaxPopulation() = %d, %d, want %d, %d", population, gen, tt.population, tt.peak)
+			}
+		})
+	}
+}
+
+// benchmarkSizes are the square grid sizes the NextGen benchmarks run on.
+var benchmarkSizes = []int{64, 256, 1024}
+
+// benchmarkGame returns a size x size game filled at random with a fixed seed, so every
+// benchmark run starts from the same grid.
+func benchmarkGame(b *testing.B, size int, opts ...Option) *Game {
+	b.Helper()
+	g, err := BuildGame(append([]Option{WithSize(size, size), WithRandom(0.3, 1)}, opts...)...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return g
+}
+
+func BenchmarkNextGen(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			g := benchmarkGame(b, size)
+			g.NextGen() // allocates the buffers reused by every later call
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				g.NextGen()
+			}
+		})
+	}
+}
+
+// TestNextGenAllocs checks that once its buffers exist a single-worker NextGen reuses
+// them instead of allocating.
+func TestNextGenAllocs(t *testing.T) {
+	g := NewGame(WithSize(128, 128), WithRandom(0.3, 1), WithWorkers(1))
+	g.Step(10)
+
+	// Growing the population history allocates once in a while, which averages to 0.
+	if allocs := testing.AllocsPerRun(100, g.NextGen); allocs != 0 {
+		t.Errorf("NextGen allocates %v times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkNextGenSingleWorker(b *testing.B) {
+	g := benchmarkGame(b, 1024, WithWorkers(1))
+	g.NextGen() // allocates the buffers reused by every later call
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		g.NextGen()
+	}
+}
+
+// BenchmarkNextGenWorkers compares the speed of a 1024x1024 generation split across
+// different numbers of workers; the speedup levels off at the number of CPUs.
+func BenchmarkNextGenWorkers(b *testing.B) {
+	counts := []int{1, 2, 4}
+	if n := runtime.NumCPU(); !slices.Contains(counts, n) {
+		counts = append(counts, n)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("%d workers", workers), func(b *testing.B) {
+			g := benchmarkGame(b, 1024, WithWorkers(workers))
+			g.NextGen() // allocates the buffers reused by every later call
+			b.ResetTimer()
+			for range b.N {
+				g.NextGen()
+			}
+		})
+	}
+}
+
+// BenchmarkNextGenSparse steps a single glider on a 1024x1024 grid with the active set,
+// and with a full scan for comparison, reporting the cells evaluated per generation.
+func BenchmarkNextGenSparse(b *testing.B) {
+	for _, full := range []bool{false, true} {
+		name := "active set"
+		if full {
+			name = "full scan"
+		}
+		b.Run(name, func(b *testing.B) {
+			g := NewGame(WithSize(1024, 1024), WithWorkers(1))
+			g.NextGen() // allocates the buffers reused by every later call
+			evaluated := 0
+			b.ResetTimer()
+			for range b.N {
+				b.StopTimer()
+				if full {
+					g.active = nil
+				}
+				evaluated += g.activeCells()
+				b.StartTimer()
+				g.NextGen()
+			}
+			b.ReportMetric(float64(evaluated)/float64(b.N), "cells/op")
+		})
+	}
+}
+
+// activeCells returns the number of cells the next NextGen evaluates, those of the active
+// rows.
+func (g *Game) activeCells() int {
+	if g.active == nil {
+		return g.grid.Width() * g.grid.Height()
+	}
+	count := 0
+	for _, active := range g.active {
+		if active {
+			count += g.grid.Width()
+		}
+	}
+	return count
+}
+
+func BenchmarkLiveNeighbors(b *testing.B) {
+	g := benchmarkGame(b, 256)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		g.LiveNeighbors(i%256, i/256%256)
+	}
+}
+
+func BenchmarkCountLiveCells(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(fmt.Sprintf("%dx%d", size, size), func(b *testing.B) {
+			g := benchmarkGame(b, size)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				g.CountLiveCells()
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Neighborhood selects which surrounding cells count as neighbors.
+type Neighborhood int
+
+const (
+	// Moore counts all 8 surrounding cells, including diagonals.
+	Moore Neighborhood = iota
+
+	// VonNeumann counts only the 4 orthogonally adjacent cells.
+	VonNeumann
+)
+
+// neighborOffsets lists the relative [row, column] offsets of the neighbors in each neighborhood.
+var neighborOffsets = [...][][2]int{
+	Moore:      {{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}},
+	VonNeumann: {{-1, 0}, {0, -1}, {0, 1}, {1, 0}},
+}
+
+// ParseNeighborhood parses a neighborhood name ("moore" or "vonneumann", case-insensitive).
+//
+// Parameters:
+//   - s: The neighborhood name.
+//
+// Returns:
+//   - The parsed Neighborhood.
+//   - An error if the name is unknown.
+func ParseNeighborhood(s string) (Neighborhood, error) {
+	switch strings.ToLower(s) {
+	case "moore":
+		return Moore, nil
+	case "vonneumann", "von-neumann":
+		return VonNeumann, nil
+	default:
+		return 0, fmt.Errorf("unknown neighborhood %q (want moore or vonneumann)", s)
+	}
+}
+
+// String returns the lower-case name of the neighborhood.
+func (n Neighborhood) String() string {
+	switch n {
+	case Moore:
+		return "moore"
+	case VonNeumann:
+		return "vonneumann"
+	default:
+		return fmt.Sprintf("Neighborhood(%d)", int(n))
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+// TestLiveNeighborsVonNeumann checks that the von Neumann neighborhood counts only the
+// four orthogonal neighbors, including across a toroidal edge, while Moore counts all
+// eight.
+func TestLiveNeighborsVonNeumann(t *testing.T) {
+	plus := [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	square := [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+	tests := []struct {
+		name         string
+		neighborhood Neighborhood
+		live         [][2]int
+		x, y         int
+		expected     int
+	}{
+		{"plus in the center", VonNeumann, plus, 5, 5, 4},
+		{"plus across the corner", VonNeumann, plus, 0, 0, 4},
+		{"full square", VonNeumann, square, 5, 5, 4},
+		{"full square, Moore", Moore, square, 5, 5, 8},
+		{"diagonals only", VonNeumann, [][2]int{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}, 5, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 10), WithNeighborhood(tt.neighborhood), WithRandom(0, 1))
+			g.Set(tt.x, tt.y, true)
+			for _, d := range tt.live {
+				g.Set(tt.x+d[0], tt.y+d[1], true)
+			}
+			if got := g.LiveNeighbors(tt.x, tt.y); got != tt.expected {
+				t.Errorf("LiveNeighbors(%d, %d) = %d, want %d", tt.x, tt.y, got, tt.expected)
+			}
+		})
+	}
+}
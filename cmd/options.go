@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// config collects the settings applied by Options when building a Game.
+type config struct {
+	width        int
+	height       int
+	rule         Rule
+	neighborhood Neighborhood
+	boundaryX    Boundary
+	boundaryY    Boundary
+
+	pattern   string // registered pattern to insert, "" for the default glider
+	patternAt *[2]int
+
+	random  bool
+	density float64
+	seed    int64
+
+	workers int
+	heatmap bool
+}
+
+// Option configures a Game built by NewGame or BuildGame.
+type Option func(*config)
+
+// WithSize sets the grid dimensions. The default is defaultGridSize x defaultGridSize.
+//
+// Parameters:
+//   - width: The number of columns in the grid.
+//   - height: The number of rows in the grid.
+func WithSize(width, height int) Option {
+	return func(c *config) {
+		c.width, c.height = width, height
+	}
+}
+
+// WithRule sets the birth/survival rule. The default is ConwayRule.
+func WithRule(rule Rule) Option {
+	return func(c *config) {
+		c.rule = rule
+	}
+}
+
+// WithNeighborhood sets the neighborhood counted by LiveNeighbors. The default is Moore.
+func WithNeighborhood(n Neighborhood) Option {
+	return func(c *config) {
+		c.neighborhood = n
+	}
+}
+
+// WithBoundary sets how the grid edges behave. The default is Toroidal.
+func WithBoundary(b Boundary) Option {
+	return func(c *config) {
+		c.boundaryX, c.boundaryY = b, b
+	}
+}
+
+// WithBoundaries sets the boundary of the row and column axes separately, e.g.
+// WithBoundaries(Dead, Toroidal) for a grid that only wraps left to right.
+//
+// Parameters:
+//   - x: The boundary beyond the top and bottom edges.
+//   - y: The boundary beyond the left and right edges.
+func WithBoundaries(x, y Boundary) Option {
+	return func(c *config) {
+		c.boundaryX, c.boundaryY = x, y
+	}
+}
+
+// WithWorkers sets the number of goroutines NextGen splits each generation across. The
+// default of 0 uses one per CPU.
+func WithWorkers(n int) Option {
+	return func(c *config) {
+		c.workers = n
+	}
+}
+
+// WithHeatmap makes NextGen count how often each cell changes state, as EnableHeatmap does.
+func WithHeatmap() Option {
+	return func(c *config) {
+		c.heatmap = true
+	}
+}
+
+// WithPattern seeds the grid with a registered pattern whose top-left corner is placed at
+// the center of the grid, replacing the default glider.
+//
+// Parameters:
+//   - name: The registered pattern name, e.g. "toad".
+func WithPattern(name string) Option {
+	return func(c *config) {
+		c.pattern, c.patternAt = name, nil
+	}
+}
+
+// WithPatternAt seeds the grid with a registered pattern whose top-left corner is placed
+// at the given origin, replacing the default glider.
+//
+// Parameters:
+//   - name: The registered pattern name, e.g. "toad".
+//   - x: The row of the pattern's top-left corner.
+//   - y: The column of the pattern's top-left corner.
+func WithPatternAt(name string, x, y int) Option {
+	return func(c *config) {
+		c.pattern, c.patternAt = name, &[2]int{x, y}
+	}
+}
+
+// WithRandom fills the grid randomly instead of placing the default glider. Each cell is
+// alive with probability density, drawn from a math/rand source seeded with seed. A
+// pattern set with WithPattern is inserted on top of the random cells.
+//
+// Parameters:
+//   - density: The probability (0-1) that a cell starts alive.
+//   - seed: The seed of the random source.
+func WithRandom(density float64, seed int64) Option {
+	return func(c *config) {
+		c.random, c.density, c.seed = true, density, seed
+	}
+}
+
+// BuildGame creates a Game configured by opts. Without options it produces a glider near
+// the center of a defaultGridSize x defaultGridSize toroidal grid running B3/S23.
+//
+// Parameters:
+//   - opts: The options to apply, in order.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error if the configuration is invalid or the pattern does not fit on the grid.
+func BuildGame(opts ...Option) (*Game, error) {
+	c := config{width: defaultGridSize, height: defaultGridSize, rule: ConwayRule}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.width < 1 || c.height < 1 {
+		return nil, fmt.Errorf("invalid grid size %dx%d", c.width, c.height)
+	}
+	if c.random && (c.density < 0 || c.density > 1) {
+		return nil, fmt.Errorf("density %v is outside [0, 1]", c.density)
+	}
+	if c.workers < 0 {
+		return nil, fmt.Errorf("invalid worker count %d", c.workers)
+	}
+
+	g := newEmptyGame(c.width, c.height)
+	g.rule, g.neighborhood = c.rule, c.neighborhood
+	g.boundaryX, g.boundaryY = c.boundaryX, c.boundaryY
+	g.workers = c.workers
+	if c.heatmap {
+		g.EnableHeatmap()
+	}
+
+	if c.random {
+		g.RandomFill(c.density, rand.New(rand.NewSource(c.seed)))
+	}
+
+	pattern := c.pattern
+	if pattern == "" && !c.random {
+		pattern = "glider"
+	}
+	if pattern != "" {
+		x, y := c.height/2, c.width/2
+		if c.patternAt != nil {
+			x, y = c.patternAt[0], c.patternAt[1]
+		}
+		if err := InsertPattern(g, pattern, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// NewGame creates a Game configured by opts, like BuildGame. Without options it produces
+// a glider near the center of a defaultGridSize x defaultGridSize toroidal grid.
+//
+// Parameters:
+//   - opts: The options to apply, in order.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//
+// NewGame panics if the configuration is invalid; use BuildGame to handle such errors.
+func NewGame(opts ...Option) *Game {
+	g, err := BuildGame(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
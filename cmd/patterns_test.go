@@ -0,0 +1,91 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestPlace checks that Place sets exactly the offset cells, wraps them on a toroidal grid
+// and rejects a placement with any cell beyond a non-wrapping edge without placing any.
+func TestPlace(t *testing.T) {
+	l := [][2]int{{0, 0}, {1, 0}, {2, 0}, {2, 1}}
+	tests := []struct {
+		name     string
+		boundary Boundary
+		ox, oy   int
+		expected [][2]int
+		wantErr  bool
+	}{
+		{"in range", Dead, 3, 5, [][2]int{{3, 5}, {4, 5}, {5, 5}, {5, 6}}, false},
+		{"touching the far corner", Dead, 7, 8, [][2]int{{7, 8}, {8, 8}, {9, 8}, {9, 9}}, false},
+		{"past the bottom edge", Dead, 8, 5, nil, true},
+		{"past the left edge", Mirror, 3, -1, nil, true},
+		{"wrapping", Toroidal, 8, 9, [][2]int{{0, 0}, {0, 9}, {8, 9}, {9, 9}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 10), WithBoundary(tt.boundary), WithRandom(0, 1))
+			err := g.Place(l, tt.ox, tt.oy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Place(%d, %d) error = %v, want error %v", tt.ox, tt.oy, err, tt.wantErr)
+			}
+			if got := g.LiveCells(); !slices.Equal(got, tt.expected) {
+				t.Errorf("live cells after Place(%d, %d) = %v, want %v", tt.ox, tt.oy, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGosperGliderGun runs the gun on a bounded 50x50 grid and checks that every period of
+// 30 generations over the first 120 adds one glider's five cells to the population.
+func TestGosperGliderGun(t *testing.T) {
+	g := NewGame(WithSize(50, 50), WithBoundary(Dead), WithPatternAt("gosperglidergun", 1, 1))
+	prev := g.CountLiveCells()
+	if prev != 36 {
+		t.Fatalf("the gun starts with %d cells, want 36", prev)
+	}
+
+	for g.Generation() < 120 {
+		g.Step(30)
+		population := g.CountLiveCells()
+		if population != prev+5 {
+			t.Errorf("population at generation %d = %d, want %d", g.Generation(), population, prev+5)
+		}
+		prev = population
+	}
+}
+
+// TestMethuselahs places each Methuselah and checks its initial cell count, and that
+// diehard dies out at generation 130 on an unbounded universe, as documented.
+func TestMethuselahs(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{"rpentomino", 5},
+		{"acorn", 7},
+		{"diehard", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(20, 20), WithPatternAt(tt.name, 8, 6))
+			if got := g.CountLiveCells(); got != tt.expected {
+				t.Errorf("CountLiveCells() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+
+	s, err := NewSparseGame(ConwayRule, Moore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Place(patterns["diehard"], 0, 0)
+	for gen := 1; gen <= 130; gen++ {
+		s.NextGen()
+		if died := s.CountLiveCells() == 0; died != (gen == 130) {
+			t.Fatalf("diehard has %d cells at generation %d", s.CountLiveCells(), gen)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Profiles written by -cpuprofile and -memprofile are read with the standard pprof tool,
+// for example:
+//
+//	go run ./cmd -headless -random -width 500 -height 500 -cpuprofile cpu.pprof
+//	go tool pprof -top cpu.pprof
+//	go tool pprof -http :8081 cpu.pprof
+//
+// A binary built with go build can be passed before the profile, as in
+// "go tool pprof gol cpu.pprof", to resolve symbols without the source tree. A heap
+// profile from -memprofile is read the same way; "-sample_index alloc_space" shows all
+// allocations made during the run rather than the memory still in use at the end.
+
+// startCPUProfile starts writing a CPU profile to the file at path.
+//
+// Parameters:
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - A function that stops profiling and closes the file; it must be called before the
+//     program exits for the profile to be complete.
+//   - An error if the file cannot be created or profiling cannot start.
+func startCPUProfile(path string) (func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("cpu profile: %w", err)
+	}
+
+	return func() error {
+		pprof.StopCPUProfile()
+		return f.Close()
+	}, nil
+}
+
+// writeHeapProfile writes a heap profile of the memory in use to the file at path, after
+// a garbage collection so that the statistics are up to date.
+//
+// Parameters:
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("heap profile: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package main
+
+import "math/rand"
+
+// NewRandomGame creates a Game whose cells are each alive with probability density.
+// The cells are drawn from a math/rand source seeded with seed, so the same arguments
+// always produce the same initial grid. It is shorthand for
+// NewGame(WithSize(width, height), WithRandom(density, seed)).
+//
+// Parameters:
+//   - width: The number of columns in the grid.
+//   - height: The number of rows in the grid.
+//   - density: The probability (0-1) that a cell starts alive.
+//   - seed: The seed of the random source.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+func NewRandomGame(width, height int, density float64, seed int64) *Game {
+	return NewGame(WithSize(width, height), WithRandom(density, seed))
+}
+
+// RandomFill reseeds the grid in place: it clears every cell, then sets each cell alive
+// with probability density, drawing from rng in row-major order. The same source state
+// therefore always produces the same grid.
+//
+// Parameters:
+//   - density: The probability (0-1) that a cell is alive.
+//   - rng: The random source.
+func (g *Game) RandomFill(density float64, rng *rand.Rand) {
+	g.Clear()
+	for x := range g.grid {
+		for y := range g.grid[x] {
+			g.grid[x][y] = rng.Float64() < density
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestNewRandomGameSeed checks that the same seed always produces the same grid and that
+// the extreme densities fill the grid with dead or live cells only.
+func TestNewRandomGameSeed(t *testing.T) {
+	first := NewRandomGame(40, 30, 0.3, 7)
+	for range 3 {
+		again := NewRandomGame(40, 30, 0.3, 7)
+		if again.CountLiveCells() != first.CountLiveCells() || !again.Equal(first) {
+			t.Fatalf("seed 7 gave %d and %d live cells", first.CountLiveCells(), again.CountLiveCells())
+		}
+	}
+	if other := NewRandomGame(40, 30, 0.3, 8); other.Equal(first) {
+		t.Error("seeds 7 and 8 gave the same grid")
+	}
+
+	tests := []struct {
+		density  float64
+		expected int
+	}{
+		{0, 0},
+		{1, 40 * 30},
+	}
+	for _, tt := range tests {
+		if got := NewRandomGame(40, 30, tt.density, 7).CountLiveCells(); got != tt.expected {
+			t.Errorf("CountLiveCells() at density %v = %d, want %d", tt.density, got, tt.expected)
+		}
+	}
+}
+
+// TestRandomFill checks that refilling with sources of the same seed reproduces the grid
+// and that a refill replaces the previous cells instead of adding to them.
+func TestRandomFill(t *testing.T) {
+	g := NewGame(WithSize(30, 20), WithRandom(0, 1))
+	g.RandomFill(0.4, rand.New(rand.NewSource(3)))
+	first := g.grid.Clone()
+
+	g.RandomFill(0.9, rand.New(rand.NewSource(5)))
+	g.RandomFill(0.4, rand.New(rand.NewSource(3)))
+	if !g.grid.Equal(first) {
+		t.Error("refilling with seed 3 gave a different grid")
+	}
+	if other := NewRandomGame(30, 20, 0.4, 3); !other.grid.Equal(first) {
+		t.Error("NewRandomGame with seed 3 differs from RandomFill with seed 3")
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// ageColors is the ANSI 256-color gradient used to colorize live cells by age, from
+// bright for newborn cells to dim for long-lived ones. Cells older than the gradient use
+// the last color.
+var ageColors = [...]int{231, 229, 227, 221, 215, 209, 203, 167, 131, 95, 59}
+
+// viewport maps positions of a rendered frame to grid cells. In follow mode the frame is
+// shifted so that the center of the live cells' bounding box appears at the center of the
+// grid; the simulation itself is never moved.
+type viewport struct {
+	dx, dy       int  // shift from grid position to frame position
+	wrapX, wrapY bool // whether cells shifted past a row or column edge reappear on the opposite edge
+	height       int
+	width        int
+}
+
+// viewport returns the viewport used to render the current generation.
+func (g *Game) viewport() viewport {
+	v := viewport{
+		wrapX:  g.boundaryX == Toroidal,
+		wrapY:  g.boundaryY == Toroidal,
+		height: g.grid.Height(),
+		width:  g.grid.Width(),
+	}
+	if !g.follow {
+		return v
+	}
+
+	if minX, minY, maxX, maxY, ok := g.BoundingBox(); ok {
+		v.dx, v.dy = v.height/2-(minX+maxX)/2, v.width/2-(minY+maxY)/2
+	}
+	return v
+}
+
+// cell returns the grid cell shown at a frame position.
+//
+// Parameters:
+//   - x: The frame row.
+//   - y: The frame column.
+//
+// Returns:
+//   - The grid row and column.
+//   - false if no grid cell is shown there because the shifted grid does not wrap.
+func (v viewport) cell(x, y int) (int, int, bool) {
+	bx, by := Dead, Dead
+	if v.wrapX {
+		bx = Toroidal
+	}
+	if v.wrapY {
+		by = Toroidal
+	}
+	sx, okX := bx.resolve(x-v.dx, v.height)
+	sy, okY := by.resolve(y-v.dy, v.width)
+	return sx, sy, okX && okY
+}
+
+// alive reports whether the grid cell shown at a frame position is alive.
+func (v viewport) alive(g *Game, x, y int) bool {
+	sx, sy, ok := v.cell(x, y)
+	return ok && g.grid[sx][sy]
+}
+
+// SetGlyphs sets the strings Render draws for live and dead cells, e.g. "█" and " ". Both
+// must have the same number of characters so that the rows stay aligned.
+//
+// Parameters:
+//   - live: The string drawn for a live cell.
+//   - dead: The string drawn for a dead cell.
+//
+// Returns:
+//   - An error if a string is empty or the two differ in length; the glyphs are left unchanged.
+func (g *Game) SetGlyphs(live, dead string) error {
+	n := utf8.RuneCountInString(live)
+	if n == 0 || utf8.RuneCountInString(dead) != n {
+		return fmt.Errorf("live and dead glyphs %q and %q must be non-empty and equally wide", live, dead)
+	}
+	g.liveGlyph, g.deadGlyph = live, dead
+	return nil
+}
+
+// glyphs returns the strings drawn for live and dead cells, liveCell and deadCell unless
+// SetGlyphs chose others.
+func (g *Game) glyphs() (live, dead string) {
+	if g.liveGlyph == "" {
+		return liveCell, deadCell
+	}
+	return g.liveGlyph, g.deadGlyph
+}
+
+// ghostGlyph returns the string drawn for a ghost cell: ghostCell with the default glyphs,
+// otherwise a light shade as wide as the live glyph.
+func (g *Game) ghostGlyph() string {
+	if g.liveGlyph == "" {
+		return ghostCell
+	}
+	return strings.Repeat("░", utf8.RuneCountInString(g.liveGlyph))
+}
+
+// SetGhostSteps makes Render keep showing a cell that died as a dim ghost for the given
+// number of frames, a purely visual trail in the style of the "Generations" automata;
+// the rule and the simulated cells are unaffected. A count of 0 disables ghosts.
+//
+// Parameters:
+//   - steps: The number of frames a ghost lasts, after which it is drawn as a dead cell.
+func (g *Game) SetGhostSteps(steps int) {
+	g.ghostSteps = max(steps, 0)
+	if g.ghostSteps == 0 {
+		g.diedAt = nil
+	}
+}
+
+// ghost reports whether a dead in-range cell died within the last ghostSteps frames.
+func (g *Game) ghost(x, y int) bool {
+	return g.diedAt != nil && g.diedAt[x][y] > 0 && g.gen-g.diedAt[x][y] < g.ghostSteps
+}
+
+// Render writes the current state of the grid to w as text framed by box-drawing borders.
+// When age coloring is enabled each live cell is wrapped in an ANSI 256-color escape
+// code chosen by its age. Cells that died within the last ghostSteps frames are drawn with
+// the ghost glyph. In follow mode the live cells are centered in the frame.
+//
+// Parameters:
+//   - w: The writer receiving the rendered grid.
+//
+// Returns:
+//   - The first error encountered while writing to w.
+func (g *Game) Render(w io.Writer) error {
+	live, dead := g.glyphs()
+	ghost := g.ghostGlyph()
+	bw := bufio.NewWriter(w)
+	border := strings.Repeat("─", g.grid.Width()*utf8.RuneCountInString(live)+2)
+	bw.WriteString("┌" + border + "┐\n")
+
+	v := g.viewport()
+	for x, row := range g.grid {
+		bw.WriteString("│ ")
+		for y := range row {
+			sx, sy, ok := v.cell(x, y)
+			cell := ok && g.grid[sx][sy]
+
+			switch {
+			case cell && g.colorAges && !g.plain:
+				color := ageColors[min(g.Age(sx, sy), len(ageColors)-1)]
+				fmt.Fprintf(bw, "\033[38;5;%dm%s\033[0m", color, live)
+			case cell:
+				bw.WriteString(live)
+			case ok && g.ghost(sx, sy) && g.colorAges && !g.plain:
+				fmt.Fprintf(bw, "\033[38;5;%dm%s\033[0m", ghostColor, ghost)
+			case ok && g.ghost(sx, sy):
+				bw.WriteString(ghost)
+			default:
+				bw.WriteString(dead)
+			}
+		}
+		bw.WriteString(" │\n")
+	}
+
+	bw.WriteString("└" + border + "┘\n")
+	return bw.Flush()
+}
+
+// String returns the current state of the grid as text framed by box-drawing borders,
+// exactly as Print displays it.
+func (g *Game) String() string {
+	var sb strings.Builder
+	_ = g.Render(&sb) // writes to a strings.Builder never fail
+	return sb.String()
+}
+
+// Print outputs the current state of the grid to the terminal with clear visual borders.
+func (g *Game) Print() {
+	_ = g.Render(os.Stdout)
+}
+
+// brailleBase is the code point of the blank Braille pattern; the low 8 bits of a
+// Braille glyph select its raised dots.
+const brailleBase = 0x2800
+
+// brailleDots maps a cell's [row][column] position within a 4x2 block to its Braille dot bit.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// RenderBraille writes the grid to w using Unicode Braille patterns, packing each 4-row by
+// 2-column block of cells into a single glyph framed by box-drawing borders. Grids whose
+// dimensions are not multiples of 4 rows and 2 columns are padded with dead cells. In
+// follow mode the live cells are centered in the frame.
+//
+// Parameters:
+//   - w: The writer receiving the rendered grid.
+//
+// Returns:
+//   - The first error encountered while writing to w.
+func (g *Game) RenderBraille(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	height, width := g.grid.Height(), g.grid.Width()
+	cols := (width + 1) / 2
+	border := strings.Repeat("─", cols+2)
+	bw.WriteString("┌" + border + "┐\n")
+
+	v := g.viewport()
+	for bx := 0; bx < height; bx += 4 {
+		bw.WriteString("│ ")
+		for by := 0; by < width; by += 2 {
+			glyph := rune(brailleBase)
+			for dx := 0; dx < 4 && bx+dx < height; dx++ {
+				for dy := 0; dy < 2 && by+dy < width; dy++ {
+					if v.alive(g, bx+dx, by+dy) {
+						glyph |= brailleDots[dx][dy]
+					}
+				}
+			}
+			bw.WriteRune(glyph)
+		}
+		bw.WriteString(" │\n")
+	}
+
+	bw.WriteString("└" + border + "┘\n")
+	return bw.Flush()
+}
+
+// ghostColor is the ANSI 256-color code of ghost cells when age coloring is enabled.
+const ghostColor = 238
+
+// halfBlockLive and halfBlockDead are the ANSI 256-color codes used by RenderHalfBlock
+// for live and dead cells.
+const (
+	halfBlockLive = 231
+	halfBlockDead = 236
+)
+
+// halfBlockGlyphs draws a plain half-block cell pair, indexed by upper<<1 | lower.
+var halfBlockGlyphs = [4]string{" ", "▄", "▀", "█"}
+
+// btoi converts a bool to 1 or 0.
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// RenderHalfBlock writes the grid to w using the upper half block '▀', drawing each pair of
+// rows as one line: the foreground color shows the upper cell and the background color
+// the lower cell. A missing lower row of a grid with an odd height is drawn as dead. In
+// follow mode the live cells are centered in the frame. A plain game draws each pair with
+// '▀', '▄', '█' or a space instead of colors.
+//
+// Parameters:
+//   - w: The writer receiving the rendered grid.
+//
+// Returns:
+//   - The first error encountered while writing to w.
+func (g *Game) RenderHalfBlock(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	height, width := g.grid.Height(), g.grid.Width()
+	border := strings.Repeat("─", width+2)
+	bw.WriteString("┌" + border + "┐\n")
+
+	v := g.viewport()
+	for x := 0; x < height; x += 2 {
+		bw.WriteString("│ ")
+		for y := 0; y < width; y++ {
+			upper, lower := v.alive(g, x, y), x+1 < height && v.alive(g, x+1, y)
+			if g.plain {
+				bw.WriteString(halfBlockGlyphs[btoi(upper)<<1|btoi(lower)])
+				continue
+			}
+			fg, bg := halfBlockDead, halfBlockDead
+			if upper {
+				fg = halfBlockLive
+			}
+			if lower {
+				bg = halfBlockLive
+			}
+			fmt.Fprintf(bw, "\033[38;5;%dm\033[48;5;%dm▀", fg, bg)
+		}
+		if !g.plain {
+			bw.WriteString("\033[0m")
+		}
+		bw.WriteString(" │\n")
+	}
+
+	bw.WriteString("└" + border + "┘\n")
+	return bw.Flush()
+}
+
+// diffRenderer draws frames of the text rendering over the previous frame, rewriting only
+// the cells that changed since then with cursor movement codes. The cursor must be at the
+// same position at the start of every frame, e.g. below a header drawn from the top-left
+// corner of the screen, and is left on the line below the frame.
+//
+// A frame is drawn in full, after clearing the rest of the screen, when there is no
+// previous frame, the grid was resized, the terminal size changed, the header wraps onto
+// a different number of lines, which moves the start of the frame, or the game shows
+// anything that can change without a cell changing state: age colors, ghosts or a
+// follow-mode view.
+type diffRenderer struct {
+	prev        *Game                              // the cells of the previous frame, nil before the first
+	size        func() (rows, cols int, err error) // reports the terminal size, nil if unknown
+	rows, cols  int                                // the terminal size when the previous frame was drawn
+	header      string                             // the text drawn above the next frame
+	headerLines int                                // the lines the header took above the previous frame
+}
+
+// render draws the game's current generation to w.
+//
+// Parameters:
+//   - g: The game to draw.
+//   - w: The writer receiving the frame, a terminal.
+//
+// Returns:
+//   - The first error encountered while writing to w.
+func (d *diffRenderer) render(g *Game, w io.Writer) error {
+	var rows, cols int
+	if d.size != nil {
+		rows, cols, _ = d.size()
+	}
+
+	prev, headerLines := d.prev, wrappedLines(d.header, cols)
+	full := prev == nil || rows != d.rows || cols != d.cols || headerLines != d.headerLines ||
+		prev.grid.Width() != g.grid.Width() || prev.grid.Height() != g.grid.Height() ||
+		(g.colorAges && !g.plain) || g.ghostSteps > 0 || g.follow
+	d.prev, d.rows, d.cols, d.headerLines = &Game{grid: g.grid.Clone()}, rows, cols, headerLines
+	if full {
+		if _, err := io.WriteString(w, "\033[J"); err != nil {
+			return err
+		}
+		return g.Render(w)
+	}
+
+	// Each changed cell is reached from the saved start of the frame: down past the top
+	// border and the rows above it, right past the left border and the cells before it.
+	live, dead := g.glyphs()
+	width := utf8.RuneCountInString(live)
+	bw := bufio.NewWriter(w)
+	bw.WriteString("\0337")
+	born, died := g.Diff(prev)
+	for _, c := range born {
+		fmt.Fprintf(bw, "\0338\033[%dB\033[%dC%s", c[0]+1, c[1]*width+2, live)
+	}
+	for _, c := range died {
+		fmt.Fprintf(bw, "\0338\033[%dB\033[%dC%s", c[0]+1, c[1]*width+2, dead)
+	}
+	fmt.Fprintf(bw, "\0338\033[%dB", g.grid.Height()+2)
+	return bw.Flush()
+}
+
+// wrappedLines returns the number of terminal lines text takes when every line longer
+// than the terminal is wrapped, counting a final line without a newline.
+//
+// Parameters:
+//   - text: The text, with lines separated by newlines.
+//   - cols: The width of the terminal, 0 if unknown, in which case no line wraps.
+func wrappedLines(text string, cols int) int {
+	lines := 0
+	for line := range strings.Lines(text) {
+		n := utf8.RuneCountInString(strings.TrimSuffix(line, "\n"))
+		if cols > 0 && n > cols {
+			lines += (n + cols - 1) / cols
+		} else {
+			lines++
+		}
+	}
+	return lines
+}
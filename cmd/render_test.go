@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestDiffRendererBlinker draws two generations of a blinker and checks that the first
+// frame is drawn in full and the second rewrites only the four cells that changed.
+func TestDiffRendererBlinker(t *testing.T) {
+	g := newEmptyGame(5, 5)
+	for y := 1; y <= 3; y++ {
+		g.grid[2][y] = true
+	}
+	d := &diffRenderer{size: func() (int, int, error) { return 24, 80, nil }}
+
+	var first strings.Builder
+	if err := d.render(g, &first); err != nil {
+		t.Fatal(err)
+	}
+	var full strings.Builder
+	if err := g.Render(&full); err != nil {
+		t.Fatal(err)
+	}
+	if want := "\033[J" + full.String(); first.String() != want {
+		t.Errorf("first frame = %q, want the full frame %q", first.String(), want)
+	}
+
+	g.NextGen()
+	var second strings.Builder
+	if err := d.render(g, &second); err != nil {
+		t.Fatal(err)
+	}
+	live, dead := g.glyphs()
+	width := utf8.RuneCountInString(live)
+	want := "\0337"
+	for _, c := range [][2]int{{1, 2}, {3, 2}} {
+		want += fmt.Sprintf("\0338\033[%dB\033[%dC%s", c[0]+1, c[1]*width+2, live)
+	}
+	for _, c := range [][2]int{{2, 1}, {2, 3}} {
+		want += fmt.Sprintf("\0338\033[%dB\033[%dC%s", c[0]+1, c[1]*width+2, dead)
+	}
+	want += "\0338\033[7B"
+	if second.String() != want {
+		t.Errorf("second frame = %q, want only the changed cells %q", second.String(), want)
+	}
+}
+
+// TestDiffRendererHeaderWrap checks that a frame is drawn in full when the header above
+// it wraps onto another line, since every change is written relative to the frame's start.
+func TestDiffRendererHeaderWrap(t *testing.T) {
+	g := newEmptyGame(5, 5)
+	d := &diffRenderer{size: func() (int, int, error) { return 24, 40, nil }}
+
+	headers := []string{strings.Repeat("a", 30) + "\n", strings.Repeat("b", 40) + "\n", strings.Repeat("c", 41) + "\n"}
+	for i, header := range headers {
+		d.header = header
+		var out strings.Builder
+		if err := d.render(g, &out); err != nil {
+			t.Fatal(err)
+		}
+		if full, want := strings.HasPrefix(out.String(), "\033[J"), i != 1; full != want {
+			t.Errorf("frame %d under a %d-column header: drawn in full = %v, want %v", i, len(header)-1, full, want)
+		}
+	}
+}
+
+// TestWrappedLines checks the number of terminal lines taken by text with lines shorter
+// than, as long as and longer than the terminal is wide.
+func TestWrappedLines(t *testing.T) {
+	tests := []struct {
+		text     string
+		cols     int
+		expected int
+	}{
+		{"", 80, 0},
+		{"status\n", 80, 1},
+		{strings.Repeat("x", 80) + "\n", 80, 1},
+		{strings.Repeat("x", 81) + "\n", 80, 2},
+		{strings.Repeat("x", 200) + "\nshort", 80, 4},
+		{strings.Repeat("x", 200) + "\n", 0, 1},
+	}
+
+	for _, tt := range tests {
+		if got := wrappedLines(tt.text, tt.cols); got != tt.expected {
+			t.Errorf("wrappedLines(%d runes, %d) = %d, want %d", len(tt.text), tt.cols, got, tt.expected)
+		}
+	}
+}
+
+// TestRenderBraille renders a 5x3 pattern, which pads both axes, and checks the glyph of
+// every 4x2 block.
+func TestRenderBraille(t *testing.T) {
+	g := newEmptyGame(3, 5)
+	for _, c := range [][2]int{{0, 0}, {3, 1}, {1, 2}, {4, 2}} {
+		g.grid[c[0]][c[1]] = true
+	}
+
+	var out strings.Builder
+	if err := g.RenderBraille(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := "┌────┐\n" +
+		"│ ⢁⠂ │\n" +
+		"│ ⠀⠁ │\n" +
+		"└────┘\n"
+	if out.String() != want {
+		t.Errorf("RenderBraille() = %q, want %q", out.String(), want)
+	}
+}
+
+// TestRenderHalfBlock renders a 4-row pattern, plain and colored, and a 3-row one whose
+// missing bottom row is drawn as dead.
+func TestRenderHalfBlock(t *testing.T) {
+	const live, dead = "\033[38;5;231m\033[48;5;236m▀", "\033[38;5;236m\033[48;5;236m▀"
+	tests := []struct {
+		name     string
+		art      string
+		plain    bool
+		expected string
+	}{
+		{"plain", "X.X\nXX.\n..X\n.XX", true, "┌─────┐\n│ █▄▀ │\n│  ▄█ │\n└─────┘\n"},
+		{"odd height", "X.X\nXX.\n.X.", true, "┌─────┐\n│ █▄▀ │\n│  ▀  │\n└─────┘\n"},
+		{"colored", "X.\n..", false, "┌────┐\n│ " + live + dead + "\033[0m │\n└────┘\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGameFromString(tt.art, 'X')
+			if err != nil {
+				t.Fatal(err)
+			}
+			g.plain = tt.plain
+
+			var out strings.Builder
+			if err := g.RenderHalfBlock(&out); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("RenderHalfBlock() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// TestRenderFollow checks that in follow mode a glider placed away from the center renders
+// exactly like one placed at the center, on grids that do and do not wrap, without moving
+// the simulated cells.
+func TestRenderFollow(t *testing.T) {
+	centered := NewGame(WithSize(11, 11), WithPatternAt("glider", 4, 4))
+	tests := []struct {
+		name     string
+		boundary Boundary
+		x, y     int
+	}{
+		{"top-left corner", Dead, 0, 0},
+		{"bottom-right corner", Dead, 8, 8},
+		{"near a toroidal edge", Toroidal, 1, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(11, 11), WithBoundary(tt.boundary), WithPatternAt("glider", tt.x, tt.y))
+			cells := g.LiveCells()
+			g.follow = true
+
+			if got, want := g.String(), centered.String(); got != want {
+				t.Errorf("follow view of a glider at %d,%d =\n%s\nwant\n%s", tt.x, tt.y, got, want)
+			}
+			if !slices.Equal(g.LiveCells(), cells) {
+				t.Error("rendering in follow mode moved the live cells")
+			}
+		})
+	}
+}
+
+// TestRenderGhost kills a lone cell with three ghost steps and checks that it is drawn
+// with the ghost glyph for exactly three frames and that ghosts never come alive.
+func TestRenderGhost(t *testing.T) {
+	g := NewGame(WithSize(5, 5), WithRandom(0, 1))
+	g.Set(2, 1, true)
+	g.SetGhostSteps(3)
+
+	for gen := 1; gen <= 5; gen++ {
+		g.NextGen()
+		want := "│ ..... │"
+		if gen <= 3 {
+			want = "│ ." + ghostCell + "... │"
+		}
+		if got := strings.Split(g.String(), "\n")[3]; got != want {
+			t.Errorf("row 2 at generation %d = %q, want %q", gen, got, want)
+		}
+		if got := g.CountLiveCells(); got != 0 {
+			t.Fatalf("CountLiveCells() at generation %d = %d, want 0", gen, got)
+		}
+	}
+}
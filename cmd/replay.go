@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Replay draws recorded grids to w one after another, pausing delay between frames, exactly
+// as they were captured and without simulating anything.
+//
+// Parameters:
+//   - snapshots: The grids to draw, in order, e.g. collected through RunOptions.Record.
+//   - w: The writer receiving the frames, typically a terminal.
+//   - delay: The pause between frames.
+//
+// Returns:
+//   - The first error encountered while writing to w.
+func Replay(snapshots []Grid, w io.Writer, delay time.Duration) error {
+	bw := bufio.NewWriter(w)
+	for i, grid := range snapshots {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		g := &Game{grid: grid, rule: ConwayRule}
+		bw.WriteString(clearScreen)
+		fmt.Fprintf(bw, "Conway's Game of Life - Replay | Frame: %d/%d | Live Cells: %d\n", i+1, len(snapshots), g.CountLiveCells())
+		if err := g.Render(bw); err != nil {
+			return err
+		}
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteRecording writes recorded grids to w as a sequence of binary snapshots, each
+// preceded by its length as an unsigned varint.
+//
+// Parameters:
+//   - w: The writer receiving the recording.
+//   - snapshots: The recorded grids.
+//
+// Returns:
+//   - An error if writing to w fails.
+func WriteRecording(w io.Writer, snapshots []Grid) error {
+	bw := bufio.NewWriter(w)
+	for _, grid := range snapshots {
+		data, _ := (&Game{grid: grid, rule: ConwayRule}).MarshalBinary()
+		bw.Write(binary.AppendUvarint(nil, uint64(len(data))))
+		bw.Write(data)
+	}
+	return bw.Flush()
+}
+
+// LoadRecording reads grids written by WriteRecording.
+//
+// Parameters:
+//   - r: The reader providing the recording.
+//
+// Returns:
+//   - The recorded grids, in order.
+//   - An error if reading fails or a snapshot is truncated or invalid.
+func LoadRecording(r io.Reader) ([]Grid, error) {
+	br := bufio.NewReader(r)
+	var snapshots []Grid
+	for {
+		size, err := binary.ReadUvarint(br)
+		if errors.Is(err, io.EOF) {
+			return snapshots, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("recording: frame %d: %w", len(snapshots)+1, err)
+		}
+		if size > 1<<40 {
+			return nil, fmt.Errorf("recording: frame %d: invalid length %d", len(snapshots)+1, size)
+		}
+
+		data := make([]byte, size)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, fmt.Errorf("recording: frame %d: %w", len(snapshots)+1, err)
+		}
+		var g Game
+		if err := g.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("recording: frame %d: %w", len(snapshots)+1, err)
+		}
+		snapshots = append(snapshots, g.grid)
+	}
+}
+
+// saveRecording writes recorded grids to the file at path.
+//
+// Parameters:
+//   - path: The destination file path, created or truncated as needed.
+//   - snapshots: The recorded grids.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func saveRecording(path string, snapshots []Grid) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteRecording(f, snapshots); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// loadRecording reads recorded grids from the file at path.
+//
+// Parameters:
+//   - path: The recording file path.
+//
+// Returns:
+//   - The recorded grids, in order.
+//   - An error if the file cannot be opened or is not a valid recording.
+func loadRecording(path string) ([]Grid, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadRecording(f)
+}
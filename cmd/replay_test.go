@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestReplay records a run, saves and reloads the recording and checks that replaying it
+// draws exactly the generations the run displayed, in order.
+func TestReplay(t *testing.T) {
+	g := NewGame(WithSize(16, 12), WithRandom(0.35, 2))
+	ref := g.Clone()
+
+	var record []Grid
+	if err := g.Run(context.Background(), io.Discard, RunOptions{Generations: 5, Headless: true, Record: &record}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(record) != 6 {
+		t.Fatalf("recorded %d frames over 5 generations, want 6", len(record))
+	}
+
+	var saved bytes.Buffer
+	if err := WriteRecording(&saved, record); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadRecording(&saved)
+	if err != nil {
+		t.Fatalf("LoadRecording() error = %v", err)
+	}
+
+	var out strings.Builder
+	if err := Replay(loaded, &out, 0); err != nil {
+		t.Fatal(err)
+	}
+	frames := strings.Split(out.String(), clearScreen)[1:]
+	if len(frames) != 6 {
+		t.Fatalf("replay drew %d frames, want 6", len(frames))
+	}
+	for i, frame := range frames {
+		if i > 0 {
+			ref.NextGen()
+		}
+		want := fmt.Sprintf("Conway's Game of Life - Replay | Frame: %d/6 | Live Cells: %d\n%s",
+			i+1, ref.CountLiveCells(), ref.String())
+		if frame != want {
+			t.Errorf("replay frame %d =\n%s\nwant generation %d\n%s", i+1, frame, i, want)
+		}
+	}
+}
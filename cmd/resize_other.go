@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// notifyResize does nothing where there is no SIGWINCH, so the terminal size is only read
+// when the run starts.
+func notifyResize(c chan<- os.Signal) {}
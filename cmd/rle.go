@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadRLE reads a pattern in Run Length Encoded format and returns a Game with the
+// pattern centered on a grid at least as large as the pattern.
+//
+// The input consists of optional '#' comment lines, a header line of the form
+// "x = N, y = M, rule = B3/S23" (the rule is optional and defaults to B3/S23), and a
+// body where 'b' is a dead cell, 'o' is a live cell, '$' ends a row and '!' ends the
+// pattern. Each tag may be preceded by a repeat count.
+//
+// Parameters:
+//   - r: The reader providing the RLE data.
+//
+// Returns:
+//   - A pointer to the initialized Game struct.
+//   - An error if the header is malformed or declares a pattern wider or taller than
+//     maxPatternSize, or the body contains unexpected characters.
+func LoadRLE(r io.Reader) (*Game, error) {
+	scanner := bufio.NewScanner(r)
+	width, height, headerFound := 0, 0, false
+	rule := ConwayRule
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var err error
+		if width, height, rule, err = parseRLEHeader(line); err != nil {
+			return nil, err
+		}
+		headerFound = true
+		break
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("rle: reading header: %w", err)
+	}
+	if !headerFound {
+		return nil, fmt.Errorf("rle: missing header line")
+	}
+
+	var body strings.Builder
+	for scanner.Scan() {
+		body.WriteString(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("rle: reading body: %w", err)
+	}
+
+	cells, err := decodeRLEBody(body.String(), width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newEmptyGame(max(width, defaultGridSize), max(height, defaultGridSize))
+	g.rule = rule
+	if err := placeCentered(g, width, height, cells); err != nil {
+		return nil, fmt.Errorf("rle: %w", err)
+	}
+
+	return g, nil
+}
+
+// parseRLEHeader parses an RLE header line such as "x = 3, y = 3, rule = B3/S23".
+//
+// Parameters:
+//   - line: The header line with surrounding whitespace removed.
+//
+// Returns:
+//   - The pattern width (x) and height (y).
+//   - The pattern rule, ConwayRule when the header does not specify one.
+//   - An error if either dimension is missing, not a positive integer or larger than
+//     maxPatternSize, or the rule is invalid.
+func parseRLEHeader(line string) (width, height int, rule Rule, err error) {
+	rule = ConwayRule
+	for _, field := range strings.Split(line, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, 0, Rule{}, fmt.Errorf("rle: malformed header field %q", strings.TrimSpace(field))
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		switch key {
+		case "x", "y":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return 0, 0, Rule{}, fmt.Errorf("rle: invalid %s dimension %q", key, value)
+			}
+			if n > maxPatternSize {
+				return 0, 0, Rule{}, fmt.Errorf("rle: %s dimension %d exceeds %d cells", key, n, maxPatternSize)
+			}
+			if key == "x" {
+				width = n
+			} else {
+				height = n
+			}
+		case "rule":
+			if rule, err = ParseRule(value); err != nil {
+				return 0, 0, Rule{}, fmt.Errorf("rle: %w", err)
+			}
+		default:
+			return 0, 0, Rule{}, fmt.Errorf("rle: unknown header field %q", key)
+		}
+	}
+
+	if width == 0 || height == 0 {
+		return 0, 0, Rule{}, fmt.Errorf("rle: header %q must define both x and y", line)
+	}
+
+	return width, height, rule, nil
+}
+
+// decodeRLEBody expands the run-length encoded body of an RLE pattern into live cells.
+//
+// Parameters:
+//   - body: The concatenated body lines.
+//   - width: The pattern width declared in the header.
+//   - height: The pattern height declared in the header.
+//
+// Returns:
+//   - The live cells as [row, column] pairs relative to the pattern's top-left corner.
+//   - An error on unexpected characters or cells outside the declared dimensions.
+func decodeRLEBody(body string, width, height int) ([][2]int, error) {
+	var cells [][2]int
+	row, col, count := 0, 0, 0
+
+	for _, ch := range body {
+		switch {
+		case ch >= '0' && ch <= '9':
+			count = count*10 + int(ch-'0')
+			continue
+		case ch == ' ' || ch == '\t' || ch == '\r':
+			continue
+		}
+
+		run := max(count, 1)
+		count = 0
+
+		switch ch {
+		case 'b':
+			col += run
+		case 'o':
+			if row >= height || col+run > width {
+				return nil, fmt.Errorf("rle: live cells at row %d exceed the declared %dx%d size", row, width, height)
+			}
+			for i := 0; i < run; i++ {
+				cells = append(cells, [2]int{row, col + i})
+			}
+			col += run
+		case '$':
+			row += run
+			col = 0
+		case '!':
+			return cells, nil
+		default:
+			return nil, fmt.Errorf("rle: unexpected character %q", ch)
+		}
+	}
+
+	return nil, fmt.Errorf("rle: missing terminating '!'")
+}
+
+// rleLineLength is the maximum length of an encoded body line written by WriteRLE.
+const rleLineLength = 70
+
+// WriteRLE encodes the live region of the grid in Run Length Encoded format.
+//
+// The header records the game's rule. Only the bounding box of the live cells is written,
+// trailing dead cells are trimmed from each row and consecutive row breaks are merged into
+// a single counted '$'. An empty grid is written with the full grid dimensions and an
+// empty body.
+//
+// Parameters:
+//   - w: The writer receiving the RLE data.
+//
+// Returns:
+//   - An error if writing to w fails.
+func (g *Game) WriteRLE(w io.Writer) error {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		_, err := fmt.Fprintf(w, "x = %d, y = %d, rule = %s\n!\n", g.grid.Width(), g.grid.Height(), g.rule)
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "x = %d, y = %d, rule = %s\n", maxY-minY+1, maxX-minX+1, g.rule)
+
+	var tokens []string
+	rowBreaks := 0
+	for x := minX; x <= maxX; x++ {
+		row := g.grid[x][minY : maxY+1]
+		end := len(row)
+		for end > 0 && !row[end-1] {
+			end--
+		}
+		if end == 0 {
+			rowBreaks++
+			continue
+		}
+		if rowBreaks > 0 {
+			tokens = append(tokens, rleToken(rowBreaks, '$'))
+		}
+		for y := 0; y < end; {
+			run := 1
+			for y+run < end && row[y+run] == row[y] {
+				run++
+			}
+			tag := 'b'
+			if row[y] {
+				tag = 'o'
+			}
+			tokens = append(tokens, rleToken(run, tag))
+			y += run
+		}
+		rowBreaks = 1
+	}
+	tokens = append(tokens, "!")
+
+	lineLen := 0
+	for _, t := range tokens {
+		if lineLen+len(t) > rleLineLength {
+			bw.WriteByte('\n')
+			lineLen = 0
+		}
+		bw.WriteString(t)
+		lineLen += len(t)
+	}
+	bw.WriteByte('\n')
+
+	return bw.Flush()
+}
+
+// rleToken formats a single run of an RLE body, omitting the count when it is 1.
+//
+// Parameters:
+//   - run: The repeat count of the tag.
+//   - tag: The RLE tag ('b', 'o' or '$').
+//
+// Returns:
+//   - The encoded token, e.g. "3o" or "b".
+func rleToken(run int, tag rune) string {
+	if run == 1 {
+		return string(tag)
+	}
+	return strconv.Itoa(run) + string(tag)
+}
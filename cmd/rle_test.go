@@ -0,0 +1,93 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestLoadRLEGlider loads a glider and checks that its cells are centered on the grid.
+func TestLoadRLEGlider(t *testing.T) {
+	const glider = "#N Glider\nx = 3, y = 3, rule = B3/S23\nbob$2bo$3o!\n"
+
+	g, err := LoadRLE(strings.NewReader(glider))
+	if err != nil {
+		t.Fatalf("LoadRLE() error = %v", err)
+	}
+	if g.grid.Width() != defaultGridSize || g.grid.Height() != defaultGridSize {
+		t.Errorf("grid is %dx%d, want %dx%d", g.grid.Width(), g.grid.Height(), defaultGridSize, defaultGridSize)
+	}
+	o := (defaultGridSize - 3) / 2
+	want := [][2]int{{o, o + 1}, {o + 1, o + 2}, {o + 2, o}, {o + 2, o + 1}, {o + 2, o + 2}}
+	if got := g.LiveCells(); !slices.Equal(got, want) {
+		t.Errorf("LiveCells() = %v, want %v", got, want)
+	}
+	if g.rule != ConwayRule {
+		t.Errorf("rule = %s, want %s", g.rule, ConwayRule)
+	}
+}
+
+// TestLoadRLEErrors checks that malformed RLE files, including headers declaring grids
+// too large to allocate, are rejected with a descriptive error.
+func TestLoadRLEErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"missing header", "#C only a comment\n", "missing header"},
+		{"malformed header field", "x = 3, y\nbob$2bo$3o!\n", "malformed header field"},
+		{"invalid dimension", "x = 3 y = 3\nbob$2bo$3o!\n", "invalid x dimension"},
+		{"missing dimension", "x = 3\nbob$2bo$3o!\n", "must define both x and y"},
+		{"unknown header field", "x = 3, y = 3, z = 1\nbob$2bo$3o!\n", "unknown header field"},
+		{"huge header", "x = 100000000, y = 100000000\no!\n", "exceeds"},
+		{"unexpected character", "x = 3, y = 3\nbob$2bq$3o!\n", "unexpected character"},
+		{"missing terminator", "x = 3, y = 3\nbob$2bo$3o\n", "missing terminating"},
+		{"cells beyond the header", "x = 2, y = 2\n3o!\n", "exceed the declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRLE(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadRLE() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestRLERoundTrip writes patterns with long runs, empty rows and a non-default rule with
+// WriteRLE and checks that LoadRLE reads back the same live cells and rule.
+func TestRLERoundTrip(t *testing.T) {
+	gun := NewGame(WithSize(60, 40), WithPatternAt("gosperglidergun", 5, 5))
+	gaps := NewGame(WithSize(30, 30), WithPatternAt("beacon", 2, 3))
+	if err := InsertPattern(gaps, "blinker", 9, 20); err != nil {
+		t.Fatal(err)
+	}
+	random := NewGame(WithSize(50, 30), WithRule(mustParseRule("B36/S23")), WithRandom(0.3, 4))
+
+	for name, g := range map[string]*Game{"gosper glider gun": gun, "empty rows": gaps, "random HighLife": random} {
+		t.Run(name, func(t *testing.T) {
+			var buf strings.Builder
+			if err := g.WriteRLE(&buf); err != nil {
+				t.Fatalf("WriteRLE() error = %v", err)
+			}
+			for line := range strings.Lines(buf.String()) {
+				if len(strings.TrimSuffix(line, "\n")) > rleLineLength {
+					t.Errorf("line %q is longer than %d characters", line, rleLineLength)
+				}
+			}
+
+			loaded, err := LoadRLE(strings.NewReader(buf.String()))
+			if err != nil {
+				t.Fatalf("LoadRLE() error = %v\n%s", err, buf.String())
+			}
+			if got, want := boxCells(loaded), boxCells(g); !slices.Equal(got, want) {
+				t.Errorf("loaded %d live cells that differ from the %d written:\n%s", len(got), len(want), buf.String())
+			}
+			if loaded.rule != g.rule {
+				t.Errorf("loaded rule %s, want %s", loaded.rule, g.rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule describes a Life-like cellular automaton in birth/survival form.
+// Birth[n] reports whether a dead cell with n live neighbors becomes alive and
+// Survival[n] reports whether a live cell with n live neighbors stays alive.
+type Rule struct {
+	Birth    [9]bool
+	Survival [9]bool
+}
+
+// ConwayRule is the classic B3/S23 rule of Conway's Game of Life.
+var ConwayRule = Rule{
+	Birth:    [9]bool{3: true},
+	Survival: [9]bool{2: true, 3: true},
+}
+
+// namedRules maps the names accepted by RuleByName to their rules.
+var namedRules = map[string]Rule{
+	"life":       ConwayRule,
+	"highlife":   mustParseRule("B36/S23"),
+	"daynight":   mustParseRule("B3678/S34678"),
+	"seeds":      mustParseRule("B2/S"),
+	"replicator": mustParseRule("B1357/S1357"),
+}
+
+// RuleByName looks up a named rule preset such as "life" or "highlife".
+// Names are case-insensitive.
+//
+// Parameters:
+//   - name: The preset name.
+//
+// Returns:
+//   - The preset Rule.
+//   - false if no preset with that name exists.
+func RuleByName(name string) (Rule, bool) {
+	r, ok := namedRules[strings.ToLower(name)]
+	return r, ok
+}
+
+// resolveRule interprets s as a preset name and falls back to parsing it as
+// "B3/S23" notation.
+//
+// Parameters:
+//   - s: A preset name or rule string.
+//
+// Returns:
+//   - The resolved Rule.
+//   - An error if s is neither a known preset nor a valid rule string.
+func resolveRule(s string) (Rule, error) {
+	if r, ok := RuleByName(s); ok {
+		return r, nil
+	}
+	return ParseRule(s)
+}
+
+// mustParseRule is like ParseRule but panics on an invalid rule string.
+// It is intended for package-level rule definitions.
+func mustParseRule(s string) Rule {
+	r, err := ParseRule(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ParseRule parses a rule in the standard "B3/S23" notation. The letters are
+// case-insensitive, the birth and survival parts may appear in either order and
+// either list of digits may be empty (e.g. "B2/S"). The legacy "S/B" digit-only
+// form used by older RLE files, such as "23/3", is also accepted.
+//
+// Parameters:
+//   - s: The rule string.
+//
+// Returns:
+//   - The parsed Rule.
+//   - An error if the notation is malformed or contains neighbor counts above 8.
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("rule %q: expected the form B<digits>/S<digits>", s)
+	}
+
+	birth, survival := parts[0], parts[1]
+	switch {
+	case hasRulePrefix(birth, 'B') && hasRulePrefix(survival, 'S'):
+		birth, survival = birth[1:], survival[1:]
+	case hasRulePrefix(birth, 'S') && hasRulePrefix(survival, 'B'):
+		birth, survival = survival[1:], birth[1:]
+	default:
+		// Legacy notation lists survival counts first: "23/3".
+		birth, survival = parts[1], parts[0]
+	}
+
+	if err := parseNeighborCounts(birth, &r.Birth); err != nil {
+		return Rule{}, fmt.Errorf("rule %q: birth: %w", s, err)
+	}
+	if err := parseNeighborCounts(survival, &r.Survival); err != nil {
+		return Rule{}, fmt.Errorf("rule %q: survival: %w", s, err)
+	}
+
+	return r, nil
+}
+
+// hasRulePrefix reports whether part starts with the given letter in either case.
+func hasRulePrefix(part string, letter byte) bool {
+	return len(part) > 0 && (part[0] == letter || part[0] == letter+('a'-'A'))
+}
+
+// parseNeighborCounts marks every digit in digits as set in counts.
+//
+// Parameters:
+//   - digits: The neighbor counts, e.g. "23".
+//   - counts: The set receiving the counts.
+//
+// Returns:
+//   - An error if digits contains anything other than the digits 0-8.
+func parseNeighborCounts(digits string, counts *[9]bool) error {
+	for _, ch := range digits {
+		if ch < '0' || ch > '8' {
+			return fmt.Errorf("invalid neighbor count %q", ch)
+		}
+		counts[ch-'0'] = true
+	}
+	return nil
+}
+
+// Next applies the rule to a single cell.
+//
+// Parameters:
+//   - alive: Whether the cell is currently alive.
+//   - neighbors: The number of live neighbors of the cell (0-8).
+//
+// Returns:
+//   - Whether the cell is alive in the next generation.
+func (r Rule) Next(alive bool, neighbors int) bool {
+	if alive {
+		return r.Survival[neighbors]
+	}
+	return r.Birth[neighbors]
+}
+
+// String formats the rule in "B3/S23" notation.
+func (r Rule) String() string {
+	var sb strings.Builder
+
+	sb.WriteByte('B')
+	for n, ok := range r.Birth {
+		if ok {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+	sb.WriteString("/S")
+	for n, ok := range r.Survival {
+		if ok {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+
+	return sb.String()
+}
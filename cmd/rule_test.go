@@ -0,0 +1,81 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestParseRule checks the accepted rule notations and rejects malformed ones.
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"B3/S23", "B3/S23", false},
+		{"b36/s23", "B36/S23", false},
+		{"S23/B3", "B3/S23", false},
+		{"23/3", "B3/S23", false},
+		{"B2/S", "B2/S", false},
+		{"B3", "", true},
+		{"B39/S23", "", true},
+		{"B3/S2x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ParseRule(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRule(%q) = %v, want an error", tt.input, r)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRule(%q) error = %v", tt.input, err)
+			}
+			if got := r.String(); got != tt.expected {
+				t.Errorf("ParseRule(%q) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestHighLifeReplicator checks that the HighLife replicator has copied itself after 12
+// generations under B36/S23, leaving two copies two cells up and to the left and two
+// cells down and to the right of where it started. Under Conway's rule the same pattern
+// does not replicate.
+func TestHighLifeReplicator(t *testing.T) {
+	replicator, err := NewGameFromString(`
+..OOO
+.O..O
+O...O
+O..O.
+OOO..`, 'O')
+	if err != nil {
+		t.Fatalf("NewGameFromString() error = %v", err)
+	}
+	place := func(rule Rule) *Game {
+		g := NewGame(WithSize(40, 40), WithRule(rule), WithBoundary(Dead), WithRandom(0, 1))
+		replicator.ForEachLive(func(x, y int) { g.Set(x+15, y+15, true) })
+		return g
+	}
+
+	var want [][2]int
+	for _, d := range []int{13, 17} {
+		replicator.ForEachLive(func(x, y int) { want = append(want, [2]int{x + d, y + d}) })
+	}
+	slices.SortFunc(want, comparePairs)
+
+	g := place(mustParseRule("B36/S23"))
+	g.Step(12)
+	if got := g.LiveCells(); !slices.Equal(got, want) {
+		t.Errorf("LiveCells() after 12 generations = %v, want %v", got, want)
+	}
+
+	g = place(ConwayRule)
+	g.Step(12)
+	if got := g.LiveCells(); slices.Equal(got, want) {
+		t.Error("the replicator replicated under B3/S23, want only under B36/S23")
+	}
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// clearScreen is the ANSI escape sequence that clears the terminal and moves the cursor home.
+const clearScreen = "\033[2J\033[H"
+
+// homeLine is the ANSI escape sequence that moves the cursor home and clears the first
+// line, used instead of clearScreen when frames are drawn over the previous one.
+const homeLine = "\033[H\033[K"
+
+// fpsWindow is the number of recent frames averaged into the frame rate shown by Run.
+const fpsWindow = 20
+
+// fpsMeter measures a rolling frame rate from the times the most recent frames were drawn.
+type fpsMeter struct {
+	times []time.Time
+}
+
+// tick records that a frame was drawn at t, forgetting frames older than fpsWindow.
+func (m *fpsMeter) tick(t time.Time) {
+	if len(m.times) == fpsWindow {
+		m.times = append(m.times[:0], m.times[1:]...)
+	}
+	m.times = append(m.times, t)
+}
+
+// rate returns the average frames per second over the recorded frames, or 0 before two
+// frames have been drawn.
+func (m *fpsMeter) rate() float64 {
+	if len(m.times) < 2 {
+		return 0
+	}
+	elapsed := m.times[len(m.times)-1].Sub(m.times[0])
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(len(m.times)-1) / elapsed.Seconds()
+}
+
+// RunOptions configures a call to Game.Run.
+type RunOptions struct {
+	// Generations is the maximum number of generations to simulate, 0 to run until a
+	// stopping condition is met or ctx is cancelled.
+	Generations int
+
+	// Delay is the initial pause between frames; the + and - keys halve or double it
+	// within [MinDelay, MaxDelay].
+	Delay time.Duration
+
+	// MinDelay and MaxDelay bound the delay adjusted with the + and - keys. Zero values
+	// select minDelay and maxDelay.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+
+	// Render draws the grid of a frame. It defaults to (*Game).Render.
+	Render func(g *Game, w io.Writer) error
+
+	// StopEmpty stops the run as soon as every cell has died.
+	StopEmpty bool
+
+	// StopStable stops the run as soon as a generation is identical to the previous one.
+	StopStable bool
+
+	// DetectCycle stops the run when a generation repeats one of the last CycleHistory
+	// generations and reports the period.
+	DetectCycle  bool
+	CycleHistory int
+
+	// Quiet prints the frames one after another instead of redrawing the screen, without
+	// ANSI escape codes or the controls line, so that a run can be logged to a file.
+	Quiet bool
+
+	// Incremental draws each frame of the default text rendering over the previous one,
+	// rewriting only the cells that changed, which avoids flicker and sends far less to
+	// the terminal. It has no effect with a custom Render or when Quiet is set.
+	Incremental bool
+
+	// Headless simulates as fast as possible without drawing any frames or reading keys,
+	// and finishes with a summary of the run instead of the final generation, for
+	// benchmarking and scripting. The stopping conditions still apply.
+	Headless bool
+
+	// Summary, if set, receives a report of the run once it ends: the generations
+	// simulated, the initial, final and peak population and how the run ended. Writing
+	// it to standard error keeps the report out of piped frames.
+	Summary io.Writer
+
+	// Keys delivers key presses for interactive control: Space pauses and resumes,
+	// Enter steps a single generation while paused, +/- change the speed and w cycles
+	// each axis's boundary one step through toroidal, dead and mirror, so a cylinder
+	// goes on to mirror,dead and toroidal,mirror rather than collapsing to one boundary.
+	// u pauses the run and steps back one generation if the game has undo enabled, and
+	// r restarts it from its initial state.
+	// A nil channel disables keyboard control.
+	Keys <-chan byte
+
+	// Paused starts the run paused, so that generations only advance on Enter.
+	Paused bool
+
+	// OnGeneration, if set, is called after every NextGen with the game in its new state,
+	// before the stopping conditions are checked. To stop the run from the hook, cancel
+	// the context passed to Run.
+	OnGeneration func(g *Game)
+
+	// Record, if set, has a copy of every displayed generation's grid appended to it, so
+	// that the run can be played back with Replay.
+	Record *[]Grid
+
+	// recorder, if set, receives every displayed generation as an animation frame.
+	recorder *gifRecorder
+}
+
+// Run simulates the game, drawing a frame for every generation to w, until
+// opts.Generations generations have been simulated, a stopping condition from opts is
+// met or ctx is cancelled. It finishes by drawing the final generation followed by a
+// line explaining why the run stopped. A headless run draws nothing but a final summary
+// of the generations simulated, the final and maximum population and the wall time.
+//
+// Parameters:
+//   - ctx: Cancelling the context ends the run after the current frame.
+//   - w: The writer receiving the frames, typically a terminal.
+//   - opts: The run configuration.
+//
+// Returns:
+//   - ctx.Err() if the run was cancelled, the first write error, or nil.
+func (g *Game) Run(ctx context.Context, w io.Writer, opts RunOptions) error {
+	render := opts.Render
+	if render == nil {
+		render = (*Game).Render
+	}
+	drawFrame, clearFrame := render, clearScreen
+	var diff *diffRenderer
+	if opts.Incremental && opts.Render == nil && !opts.Quiet {
+		size, stop := watchTerminalSize(terminalSize)
+		defer stop()
+		diff = &diffRenderer{size: size}
+		drawFrame, clearFrame = diff.render, homeLine
+	}
+
+	var cycles *cycleDetector
+	if opts.DetectCycle {
+		cycles = newCycleDetector(opts.CycleHistory)
+		cycles.observe(g.Hash(), g.gen)
+	}
+
+	bw := bufio.NewWriter(w)
+	stableGen, extinctGen, period := -1, -1, 0
+	paused, stepOnce := opts.Paused, false
+	frameDelay := opts.Delay
+	fastest, slowest := cmp.Or(opts.MinDelay, minDelay), cmp.Or(opts.MaxDelay, maxDelay)
+	var fps fpsMeter
+	start, startGen := time.Now(), g.gen
+	startPopulation := g.CountLiveCells()
+
+loop:
+	for i := 0; (opts.Generations == 0 || i < opts.Generations) && ctx.Err() == nil; {
+		if !opts.Headless {
+			if !opts.Quiet {
+				bw.WriteString(clearFrame)
+			}
+			fps.tick(time.Now())
+
+			status := ""
+			if paused {
+				status = " | PAUSED"
+			}
+			header := fmt.Sprintf("Conway's Game of Life - Rule: %s | Generation: %d | Live Cells: %d | Births: %d | Deaths: %d | Boundary: %s | Delay: %v | FPS: %.1f%s\n",
+				g.rule, g.gen, g.CountLiveCells(), g.LastBirths(), g.LastDeaths(), formatBoundaries(g.boundaryX, g.boundaryY), frameDelay, fps.rate(), status)
+			bw.WriteString(header)
+			if diff != nil {
+				diff.header = header
+			}
+
+			if err := drawFrame(g, bw); err != nil {
+				return err
+			}
+			switch {
+			case opts.Quiet:
+			case opts.Keys != nil:
+				bw.WriteString("Press Space to pause/resume, Enter to step while paused, +/- to change speed, w to change edges, u to undo, r to restart, Ctrl+C to exit\n")
+			default:
+				bw.WriteString("Press Ctrl+C to exit\n")
+			}
+			if err := bw.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if !paused || stepOnce {
+			stepOnce = false
+			if opts.recorder != nil {
+				opts.recorder.add(g)
+			}
+			if opts.Record != nil {
+				*opts.Record = append(*opts.Record, g.grid.Clone())
+			}
+
+			g.NextGen()
+			i++
+			if opts.OnGeneration != nil {
+				opts.OnGeneration(g)
+			}
+			if opts.StopEmpty && g.CountLiveCells() == 0 {
+				extinctGen = g.gen
+				break
+			}
+			if opts.StopStable && g.LastBirths() == 0 && g.LastDeaths() == 0 {
+				stableGen = g.gen - 1
+				break
+			}
+			if cycles != nil {
+				if p, ok := cycles.observe(g.Hash(), g.gen); ok {
+					period = p
+					break
+				}
+			}
+		}
+
+		if opts.Headless {
+			continue
+		}
+
+		// Wait for the next frame; a key press ends the wait early so it takes effect at once.
+		select {
+		case key := <-opts.Keys:
+			switch key {
+			case ' ':
+				paused = !paused
+			case '\n', '\r', 'n':
+				stepOnce = paused
+			case '+', '=':
+				frameDelay = max(frameDelay/2, fastest)
+			case '-', '_':
+				frameDelay = min(frameDelay*2, slowest)
+			case 'u':
+				paused = true
+				g.Undo()
+				cycles = restartCycles(cycles, g, opts.CycleHistory)
+			case 'r':
+				g.Reset()
+				cycles = restartCycles(cycles, g, opts.CycleHistory)
+			case 'w':
+				g.SetBoundaries((g.boundaryX+1)%(Mirror+1), (g.boundaryY+1)%(Mirror+1))
+			}
+		case <-time.After(frameDelay):
+		case <-ctx.Done():
+			break loop
+		}
+	}
+
+	if opts.recorder != nil {
+		opts.recorder.add(g)
+	}
+	if opts.Record != nil {
+		*opts.Record = append(*opts.Record, g.grid.Clone())
+	}
+
+	// Final state display
+	maxPopulation, maxPopulationGen := g.MaxPopulation()
+	switch {
+	case opts.Headless:
+		fmt.Fprintf(bw, "Generations: %d | Final Population: %d | Max Population: %d | Wall Time: %v\n",
+			g.gen-startGen, g.CountLiveCells(), maxPopulation, time.Since(start).Round(time.Microsecond))
+	default:
+		if !opts.Quiet {
+			bw.WriteString(clearScreen)
+		}
+		fmt.Fprintf(bw, "Conway's Game of Life - Final Generation: %d | Live Cells: %d\n",
+			g.gen, g.CountLiveCells())
+		if err := render(g, bw); err != nil {
+			return err
+		}
+	}
+	if extinctGen >= 0 {
+		fmt.Fprintf(bw, "Extinct at generation %d\n", extinctGen)
+	}
+	if stableGen >= 0 {
+		fmt.Fprintf(bw, "Stabilized at generation %d\n", stableGen)
+	}
+	if period > 0 {
+		fmt.Fprintf(bw, "Cycle detected at generation %d: period %d\n", g.gen, period)
+	}
+	if elapsed := time.Since(start); g.gen > startGen && elapsed > 0 {
+		fmt.Fprintf(bw, "Average speed: %.1f generations/s\n", float64(g.gen-startGen)/elapsed.Seconds())
+	}
+	if ctx.Err() != nil {
+		fmt.Fprintf(bw, "Interrupted at generation %d\n", g.gen)
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if opts.Summary != nil {
+		summary := runSummary{
+			generations:       g.gen - startGen,
+			initialPopulation: startPopulation,
+			finalPopulation:   g.CountLiveCells(),
+			peakPopulation:    maxPopulation,
+			peakGen:           maxPopulationGen,
+			extinctGen:        extinctGen,
+			stableGen:         stableGen,
+			period:            period,
+			periodGen:         g.gen,
+			interrupted:       ctx.Err() != nil,
+		}
+		if err := summary.write(opts.Summary); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// restartCycles replaces a cycle detector after the game jumped back to an earlier
+// state, so that replaying generations it has already seen is not taken for a cycle.
+//
+// Parameters:
+//   - cycles: The run's cycle detector, nil when cycle detection is off.
+//   - g: The game in its new state.
+//   - history: The number of generations the detector remembers.
+//
+// Returns:
+//   - A detector that has seen only the current generation, or nil if cycles is nil.
+func restartCycles(cycles *cycleDetector, g *Game, history int) *cycleDetector {
+	if cycles == nil {
+		return nil
+	}
+	cycles = newCycleDetector(history)
+	cycles.observe(g.Hash(), g.gen)
+	return cycles
+}
+
+// runSummary collects the statistics of a finished run reported through
+// RunOptions.Summary.
+type runSummary struct {
+	generations       int
+	initialPopulation int
+	finalPopulation   int
+	peakPopulation    int
+	peakGen           int // generation at which the peak population was first reached
+	extinctGen        int // -1 unless the run stopped because every cell died
+	stableGen         int // -1 unless the run stopped because the grid stopped changing
+	period            int // 0 unless the run stopped at a repeated generation
+	periodGen         int
+	interrupted       bool
+}
+
+// write prints the summary to w as an aligned list.
+//
+// Parameters:
+//   - w: The writer receiving the report.
+//
+// Returns:
+//   - An error if writing to w fails.
+func (s runSummary) write(w io.Writer) error {
+	outcome := "generation limit reached"
+	switch {
+	case s.extinctGen >= 0:
+		outcome = fmt.Sprintf("extinct at generation %d", s.extinctGen)
+	case s.stableGen >= 0:
+		outcome = fmt.Sprintf("stabilized at generation %d", s.stableGen)
+	case s.period > 0:
+		outcome = fmt.Sprintf("oscillating with period %d, detected at generation %d", s.period, s.periodGen)
+	case s.interrupted:
+		outcome = "interrupted"
+	}
+
+	_, err := fmt.Fprintf(w, "Run summary:\n"+
+		"  Generations:        %d\n"+
+		"  Initial population: %d\n"+
+		"  Final population:   %d\n"+
+		"  Peak population:    %d at generation %d\n"+
+		"  Outcome:            %s\n",
+		s.generations, s.initialPopulation, s.finalPopulation, s.peakPopulation, s.peakGen, outcome)
+	return err
+}
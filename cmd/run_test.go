@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunCancel cancels the context from the generation hook and checks that the run
+// stops right after that generation.
+func TestRunCancel(t *testing.T) {
+	g := NewGame()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out strings.Builder
+	err := g.Run(ctx, &out, RunOptions{
+		Delay: time.Millisecond,
+		Quiet: true,
+		OnGeneration: func(g *Game) {
+			if g.Generation() == 3 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if g.Generation() != 3 {
+		t.Errorf("Generation() = %d after cancelling at 3", g.Generation())
+	}
+	if !strings.Contains(out.String(), "Interrupted at generation 3") {
+		t.Errorf("output does not report the interruption:\n%s", out.String())
+	}
+}
+
+// TestRunTimeout checks that an unlimited run ends when its context times out.
+func TestRunTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- NewGame().Run(ctx, io.Discard, RunOptions{Delay: time.Millisecond, Quiet: true}) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after its context timed out")
+	}
+}
+
+// TestRunGenerations checks that a run stops after the requested number of generations.
+func TestRunGenerations(t *testing.T) {
+	g := NewGame()
+	if err := g.Run(context.Background(), io.Discard, RunOptions{Generations: 5, Headless: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if g.Generation() != 5 {
+		t.Errorf("Generation() = %d, want 5", g.Generation())
+	}
+}
+
+// TestRunKeyCyclesEachBoundary presses w three times on a cylinder and checks that each
+// axis steps through toroidal, dead and mirror on its own, so the cylinder comes back
+// instead of collapsing to a single boundary.
+func TestRunKeyCyclesEachBoundary(t *testing.T) {
+	g := NewGame(WithBoundaries(Dead, Toroidal))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keys := make(chan byte)
+	go func() {
+		for range 3 {
+			keys <- 'w'
+		}
+		cancel()
+	}()
+
+	var out strings.Builder
+	err := g.Run(ctx, &out, RunOptions{Delay: time.Hour, Quiet: true, Paused: true, Keys: keys})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	for _, want := range []string{"Boundary: mirror,dead", "Boundary: toroidal,mirror"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output has no frame with %q:\n%s", want, out.String())
+		}
+	}
+	if g.boundaryX != Dead || g.boundaryY != Toroidal {
+		t.Errorf("boundaries are %s after three presses, want dead,toroidal",
+			formatBoundaries(g.boundaryX, g.boundaryY))
+	}
+}
+
+// TestRunOnGeneration accumulates the population from the generation hook and checks that
+// the hook sees every generation in its new state.
+func TestRunOnGeneration(t *testing.T) {
+	g := NewGame(WithSize(30, 30), WithRandom(0.3, 6))
+	ref := g.Clone()
+
+	var gens, populations []int
+	err := g.Run(context.Background(), io.Discard, RunOptions{
+		Generations: 10,
+		Headless:    true,
+		OnGeneration: func(g *Game) {
+			gens = append(gens, g.Generation())
+			populations = append(populations, g.CountLiveCells())
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for i := range 10 {
+		ref.NextGen()
+		if i >= len(gens) || gens[i] != i+1 || populations[i] != ref.CountLiveCells() {
+			t.Fatalf("hook calls %v with populations %v, want generation %d with %d cells",
+				gens, populations, i+1, ref.CountLiveCells())
+		}
+	}
+	if len(gens) != 10 {
+		t.Errorf("hook called %d times, want 10", len(gens))
+	}
+}
+
+// TestRunStopEmpty runs two isolated cells under B2/S, where no cell survives and no cell
+// has the two neighbors needed for a birth, and checks that the run stops as soon as they
+// die, or runs on when StopEmpty is off.
+func TestRunStopEmpty(t *testing.T) {
+	rule := mustParseRule("B2/S")
+	if rule.String() != "B2/S" {
+		t.Fatalf("ParseRule(B2/S).String() = %q, want B2/S", rule.String())
+	}
+
+	tests := []struct {
+		name      string
+		stopEmpty bool
+		expected  int
+	}{
+		{"stop empty", true, 1},
+		{"run on", false, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 10), WithRule(rule), WithRandom(0, 1))
+			g.Set(2, 2, true)
+			g.Set(7, 7, true)
+
+			var out strings.Builder
+			err := g.Run(context.Background(), &out, RunOptions{Generations: 50, Headless: true, StopEmpty: tt.stopEmpty})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if g.Generation() != tt.expected || g.CountLiveCells() != 0 {
+				t.Errorf("run ended at generation %d with %d cells, want generation %d with 0",
+					g.Generation(), g.CountLiveCells(), tt.expected)
+			}
+			if got := strings.Contains(out.String(), "Extinct at generation 1\n"); got != tt.stopEmpty {
+				t.Errorf("output reports extinction = %v, want %v:\n%s", got, tt.stopEmpty, out.String())
+			}
+		})
+	}
+}
+
+// TestRunSummary runs an r-pentomino and checks that the summary reports the peak
+// population and the first generation it was reached at, as found in the population
+// history.
+func TestRunSummary(t *testing.T) {
+	g := NewGame(WithSize(40, 40), WithBoundary(Dead), WithPatternAt("rpentomino", 18, 18))
+
+	var summary strings.Builder
+	err := g.Run(context.Background(), io.Discard, RunOptions{Generations: 80, Headless: true, Summary: &summary})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	history := g.PopulationHistory()
+	peak := slices.Max(history)
+	peakGen := slices.Index(history, peak)
+	if peakGen == 0 || peakGen == len(history)-1 {
+		t.Fatalf("the population peaks at generation %d, want a peak inside the run", peakGen)
+	}
+	want := fmt.Sprintf("Run summary:\n"+
+		"  Generations:        80\n"+
+		"  Initial population: 5\n"+
+		"  Final population:   %d\n"+
+		"  Peak population:    %d at generation %d\n"+
+		"  Outcome:            generation limit reached\n", g.CountLiveCells(), peak, peakGen)
+	if summary.String() != want {
+		t.Errorf("summary =\n%s\nwant\n%s", summary.String(), want)
+	}
+}
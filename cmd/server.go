@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+)
+
+// frameJSON is the JSON payload of a generation streamed by the HTTP server. Live cells
+// are [row, column] pairs, as in game snapshots.
+type frameJSON struct {
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
+	Generation int      `json:"generation"`
+	Population int      `json:"population"`
+	Cells      [][2]int `json:"cells"`
+}
+
+// server streams the generations of a running game to web browsers with Server-Sent
+// Events. The simulation publishes every generation; clients that connect later start
+// from the latest one and slow clients skip generations rather than holding up the run.
+type server struct {
+	mu      sync.Mutex
+	latest  []byte                   // the most recent frame, nil until the first publish
+	state   []byte                   // the most recent game snapshot, as written by MarshalJSON
+	clients map[chan []byte]struct{} // one buffered channel per connected client
+
+	// Totals exported at "/metrics", accumulated over every publish after the first.
+	generations uint64
+	births      uint64
+	deaths      uint64
+	population  int
+}
+
+// newServer creates a server with no published generation and no clients.
+func newServer() *server {
+	return &server{clients: make(map[chan []byte]struct{})}
+}
+
+// publish encodes the game's current generation and sends it to every connected client.
+//
+// Parameters:
+//   - g: The game whose state is published.
+func (s *server) publish(g *Game) {
+	cells := g.LiveCells()
+	if cells == nil {
+		cells = [][2]int{}
+	}
+	data, err := json.Marshal(frameJSON{
+		Width:      g.grid.Width(),
+		Height:     g.grid.Height(),
+		Generation: g.gen,
+		Population: len(cells),
+		Cells:      cells,
+	})
+	if err != nil {
+		log.Printf("http: encoding generation %d: %v", g.gen, err)
+		return
+	}
+	state, err := g.MarshalJSON()
+	if err != nil {
+		log.Printf("http: encoding generation %d: %v", g.gen, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latest != nil {
+		s.generations++
+		s.births += uint64(g.LastBirths())
+		s.deaths += uint64(g.LastDeaths())
+	}
+	s.population = len(cells)
+	s.latest, s.state = data, state
+	for c := range s.clients {
+		select {
+		case c <- data:
+		default: // the client is still sending an earlier frame; it skips this one
+		}
+	}
+}
+
+// handler returns the HTTP handler serving the viewer page at "/", the event stream at
+// "/events", a snapshot of the current generation at "/state" and Prometheus metrics at
+// "/metrics".
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /events", s.handleEvents)
+	mux.HandleFunc("GET /state", s.handleState)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return mux
+}
+
+// handleIndex serves the page that draws the streamed generations on a canvas.
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, indexHTML)
+}
+
+// handleState serves the latest published generation as a JSON game snapshot, the same
+// document -save writes for a .json path.
+func (s *server) handleState(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	state := s.state
+	s.mu.Unlock()
+	if state == nil {
+		http.Error(w, "no generation published yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(state)
+}
+
+// handleMetrics serves the run's counters and current population in the Prometheus text
+// exposition format, so the simulation can be scraped without a client library.
+func (s *server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	generations, births, deaths, population := s.generations, s.births, s.deaths, s.population
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprintf(w, "# HELP gol_generations_total Generations simulated since the server started.\n"+
+		"# TYPE gol_generations_total counter\n"+
+		"gol_generations_total %d\n"+
+		"# HELP gol_population Live cells in the current generation.\n"+
+		"# TYPE gol_population gauge\n"+
+		"gol_population %d\n"+
+		"# HELP gol_births_total Dead cells that came alive since the server started.\n"+
+		"# TYPE gol_births_total counter\n"+
+		"gol_births_total %d\n"+
+		"# HELP gol_deaths_total Live cells that died since the server started.\n"+
+		"# TYPE gol_deaths_total counter\n"+
+		"gol_deaths_total %d\n",
+		generations, population, births, deaths)
+}
+
+// handleEvents streams every published generation to one client as Server-Sent Events
+// until the client disconnects.
+func (s *server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	c := make(chan []byte, 1)
+	s.mu.Lock()
+	if s.latest != nil {
+		c <- s.latest
+	}
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	flusher.Flush()
+
+	for {
+		select {
+		case data := <-c:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// indexHTML is the viewer page served at "/".
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Conway's Game of Life</title>
+<style>
+body { background: #111; color: #eee; font-family: monospace; text-align: center; }
+canvas { background: #222; image-rendering: pixelated; }
+</style>
+</head>
+<body>
+<p id="status">Waiting for the first generation...</p>
+<canvas id="grid"></canvas>
+<script>
+const canvas = document.getElementById("grid");
+const ctx = canvas.getContext("2d");
+const status = document.getElementById("status");
+const cell = 8;
+
+new EventSource("/events").onmessage = (event) => {
+	const frame = JSON.parse(event.data);
+	canvas.width = frame.width * cell;
+	canvas.height = frame.height * cell;
+	ctx.fillStyle = "#eee";
+	for (const [row, col] of frame.cells) {
+		ctx.fillRect(col * cell, row * cell, cell - 1, cell - 1);
+	}
+	status.textContent = "Generation " + frame.generation + " | Live Cells: " + frame.population;
+};
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestServerState publishes a seeded game and checks that /state serves its snapshot as
+// JSON with the game's dimensions, generation, rule, boundary and live cells.
+func TestServerState(t *testing.T) {
+	s := newServer()
+	ts := httptest.NewServer(s.handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GET /state before a publish: status %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	g := NewGame(WithSize(30, 20), WithBoundaries(Dead, Toroidal), WithRandom(0.3, 42))
+	g.Step(5)
+	s.publish(g)
+
+	resp, err = http.Get(ts.URL + "/state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /state: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var state gameJSON
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil {
+		t.Fatalf("decoding /state: %v", err)
+	}
+	if state.Width != 30 || state.Height != 20 || state.Generation != 5 {
+		t.Errorf("state is %dx%d at generation %d, want 30x20 at generation 5",
+			state.Width, state.Height, state.Generation)
+	}
+	if state.Rule != "B3/S23" || state.Boundary != "dead,toroidal" {
+		t.Errorf("state has rule %q and boundary %q, want B3/S23 and dead,toroidal", state.Rule, state.Boundary)
+	}
+	if !slices.Equal(state.Cells, g.LiveCells()) {
+		t.Errorf("state has %d live cells that differ from the game's %d", len(state.Cells), g.CountLiveCells())
+	}
+}
+
+// TestServerEvents connects to /events and checks that the stream starts with the latest
+// published generation and then carries each new one as a JSON frame.
+func TestServerEvents(t *testing.T) {
+	s := newServer()
+	ts := httptest.NewServer(s.handler())
+	defer ts.Close()
+
+	g := NewGame(WithSize(20, 10), WithPatternAt("glider", 2, 2))
+	s.publish(g)
+
+	resp, err := http.Get(ts.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	events := bufio.NewReader(resp.Body)
+	next := func() frameJSON {
+		t.Helper()
+		for {
+			line, err := events.ReadString('\n')
+			if err != nil {
+				t.Fatalf("reading the event stream: %v", err)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				var frame frameJSON
+				if err := json.Unmarshal([]byte(data), &frame); err != nil {
+					t.Fatalf("decoding event %q: %v", data, err)
+				}
+				return frame
+			}
+		}
+	}
+
+	// The first event is only sent once the client is registered, so the generations
+	// published after it reach the stream.
+	for gen := range 3 {
+		if gen > 0 {
+			g.NextGen()
+			s.publish(g)
+		}
+		frame := next()
+		if frame.Width != 20 || frame.Height != 10 || frame.Generation != gen || frame.Population != 5 {
+			t.Errorf("event %d is %dx%d at generation %d with %d cells, want 20x10 at generation %d with 5",
+				gen, frame.Width, frame.Height, frame.Generation, frame.Population, gen)
+		}
+		if !slices.Equal(frame.Cells, g.LiveCells()) {
+			t.Errorf("event %d has cells %v, want %v", gen, frame.Cells, g.LiveCells())
+		}
+	}
+}
+
+// TestServerMetrics publishes a blinker and four of its generations and checks that
+// /metrics declares each metric's type and reports the totals of those four steps.
+func TestServerMetrics(t *testing.T) {
+	s := newServer()
+	ts := httptest.NewServer(s.handler())
+	defer ts.Close()
+
+	g := NewGame(WithSize(5, 5), WithPatternAt("blinker", 1, 1))
+	s.publish(g)
+	for range 4 {
+		g.NextGen()
+		s.publish(g)
+	}
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", ct)
+	}
+
+	var types []string
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if typ, ok := strings.CutPrefix(line, "# TYPE "); ok {
+			types = append(types, typ)
+		} else if name, value, ok := strings.Cut(line, " "); ok && !strings.HasPrefix(line, "#") {
+			values[name] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	wantTypes := []string{"gol_generations_total counter", "gol_population gauge", "gol_births_total counter",
+		"gol_deaths_total counter"}
+	if !slices.Equal(types, wantTypes) {
+		t.Errorf("# TYPE lines = %q, want %q", types, wantTypes)
+	}
+	wantValues := map[string]string{"gol_generations_total": "4", "gol_population": "3", "gol_births_total": "8",
+		"gol_deaths_total": "8"}
+	for name, want := range wantValues {
+		if values[name] != want {
+			t.Errorf("%s = %q, want %s", name, values[name], want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Universe is the behavior shared by the bounded Game and the unbounded SparseGame, so
+// code driving a simulation does not depend on how the cells are stored.
+type Universe interface {
+	Get(x, y int) bool
+	Set(x, y int, alive bool)
+	LiveNeighbors(x, y int) int
+	NextGen()
+	CountLiveCells() int
+	ForEachLive(fn func(x, y int))
+	BoundingBox() (minX, minY, maxX, maxY int, ok bool)
+}
+
+var (
+	_ Universe = (*Game)(nil)
+	_ Universe = (*SparseGame)(nil)
+)
+
+// SparseGame is an unbounded universe that stores only its live cells, keyed by
+// [row, column]. Patterns can grow or travel in any direction without wrapping or hitting
+// an edge, and each generation only examines the live cells and their neighbors.
+type SparseGame struct {
+	cells        map[[2]int]bool
+	gen          int
+	rule         Rule
+	neighborhood Neighborhood
+
+	// lastBirths and lastDeaths count the cells that came alive and died in the most recent NextGen.
+	lastBirths int
+	lastDeaths int
+}
+
+// NewSparseGame creates an empty unbounded universe.
+//
+// Parameters:
+//   - rule: The birth and survival rule to apply.
+//   - neighborhood: The neighborhood whose cells are counted.
+//
+// Returns:
+//   - A pointer to the initialized SparseGame struct.
+//   - An error if the rule gives birth on 0 neighbors, which would fill the infinite plane.
+func NewSparseGame(rule Rule, neighborhood Neighborhood) (*SparseGame, error) {
+	if rule.Birth[0] {
+		return nil, fmt.Errorf("sparse: rule %s gives birth on 0 neighbors", rule)
+	}
+	return &SparseGame{cells: make(map[[2]int]bool), rule: rule, neighborhood: neighborhood}, nil
+}
+
+// Get reports whether the cell at the given coordinate is alive.
+//
+// Parameters:
+//   - x: The row index, any integer.
+//   - y: The column index, any integer.
+//
+// Returns:
+//   - true if the cell is alive.
+func (s *SparseGame) Get(x, y int) bool {
+	return s.cells[[2]int{x, y}]
+}
+
+// Set makes the cell at the given coordinate alive or dead.
+//
+// Parameters:
+//   - x: The row index, any integer.
+//   - y: The column index, any integer.
+//   - alive: The new state of the cell.
+func (s *SparseGame) Set(x, y int, alive bool) {
+	if alive {
+		s.cells[[2]int{x, y}] = true
+	} else {
+		delete(s.cells, [2]int{x, y})
+	}
+}
+
+// Place makes the given cells alive, offset by an origin.
+//
+// Parameters:
+//   - cells: The live cells as [row, column] pairs relative to the origin.
+//   - ox: The row of the origin.
+//   - oy: The column of the origin.
+func (s *SparseGame) Place(cells [][2]int, ox, oy int) {
+	for _, c := range cells {
+		s.Set(ox+c[0], oy+c[1], true)
+	}
+}
+
+// LiveNeighbors counts the live neighbors of a cell in the game's neighborhood.
+//
+// Parameters:
+//   - x: The row index, any integer.
+//   - y: The column index, any integer.
+//
+// Returns:
+//   - The number of live neighbors.
+func (s *SparseGame) LiveNeighbors(x, y int) int {
+	count := 0
+	for _, d := range neighborOffsets[s.neighborhood] {
+		if s.Get(x+d[0], y+d[1]) {
+			count++
+		}
+	}
+	return count
+}
+
+// NextGen computes the next generation by applying the game's rule. Every live cell adds
+// one to the neighbor count of each cell around it, so only live cells and the cells next
+// to them are ever evaluated.
+func (s *SparseGame) NextGen() {
+	counts := make(map[[2]int]int, len(s.cells)*len(neighborOffsets[s.neighborhood]))
+	for c := range s.cells {
+		for _, d := range neighborOffsets[s.neighborhood] {
+			counts[[2]int{c[0] + d[0], c[1] + d[1]}]++
+		}
+	}
+
+	next := make(map[[2]int]bool, len(s.cells))
+	births, deaths := 0, 0
+	for c, n := range counts {
+		alive := s.cells[c]
+		if s.rule.Next(alive, n) {
+			next[c] = true
+			if !alive {
+				births++
+			}
+		}
+	}
+	for c := range s.cells {
+		if next[c] {
+			continue
+		}
+		if _, counted := counts[c]; !counted && s.rule.Next(true, 0) {
+			next[c] = true
+			continue
+		}
+		deaths++
+	}
+
+	s.cells = next
+	s.gen++
+	s.lastBirths, s.lastDeaths = births, deaths
+}
+
+// LastBirths returns the number of cells that came alive in the most recent NextGen.
+func (s *SparseGame) LastBirths() int {
+	return s.lastBirths
+}
+
+// LastDeaths returns the number of cells that died in the most recent NextGen.
+func (s *SparseGame) LastDeaths() int {
+	return s.lastDeaths
+}
+
+// CountLiveCells counts the total number of live cells in the universe.
+//
+// Returns:
+//   - The count of live cells as an integer.
+func (s *SparseGame) CountLiveCells() int {
+	return len(s.cells)
+}
+
+// ForEachLive calls fn with the row and column of every live cell, in row-major order.
+// Only the live cells are visited, however far apart they are.
+//
+// Parameters:
+//   - fn: The callback invoked for each live cell.
+func (s *SparseGame) ForEachLive(fn func(x, y int)) {
+	cells := slices.SortedFunc(maps.Keys(s.cells), func(a, b [2]int) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	for _, c := range cells {
+		fn(c[0], c[1])
+	}
+}
+
+// BoundingBox finds the smallest rectangle containing every live cell.
+//
+// Returns:
+//   - The top-left (minX, minY) and bottom-right (maxX, maxY) corners as row and column indices.
+//   - false if the universe has no live cells.
+func (s *SparseGame) BoundingBox() (minX, minY, maxX, maxY int, ok bool) {
+	for c := range s.cells {
+		if !ok {
+			minX, minY, maxX, maxY, ok = c[0], c[1], c[0], c[1], true
+			continue
+		}
+		minX, maxX = min(minX, c[0]), max(maxX, c[0])
+		minY, maxY = min(minY, c[1]), max(maxY, c[1])
+	}
+	return minX, minY, maxX, maxY, ok
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// sparseCells returns the live cells of a universe shifted by (dx, dy), sorted by row and
+// then column.
+func sparseCells(u Universe, dx, dy int) [][2]int {
+	var cells [][2]int
+	u.ForEachLive(func(x, y int) { cells = append(cells, [2]int{x + dx, y + dy}) })
+	slices.SortFunc(cells, comparePairs)
+	return cells
+}
+
+// TestSparseGameGlider steps a glider on a SparseGame and on a dense Game side by side and
+// checks that both hold the same cells every generation and that the glider moves one cell
+// down and right per period of 4.
+func TestSparseGameGlider(t *testing.T) {
+	s, err := NewSparseGame(ConwayRule, Moore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Place(patterns["glider"], 0, 0)
+	g := NewGame(WithSize(20, 20), WithPatternAt("glider", 0, 0))
+	start := sparseCells(s, 1, 1)
+
+	for range 4 {
+		s.NextGen()
+		g.NextGen()
+		if got, want := sparseCells(s, 0, 0), sparseCells(g, 0, 0); !slices.Equal(got, want) {
+			t.Fatalf("generation %d: sparse cells %v, want the dense game's %v", g.Generation(), got, want)
+		}
+	}
+	if got := sparseCells(s, 0, 0); !slices.Equal(got, start) {
+		t.Errorf("glider after 4 generations = %v, want %v", got, start)
+	}
+}
+
+// TestSparseGameTravels runs a glider for 4000 generations from negative coordinates and
+// checks that it arrives intact 1000 cells down and right, with no edge in the way.
+func TestSparseGameTravels(t *testing.T) {
+	s, err := NewSparseGame(ConwayRule, Moore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Place(patterns["glider"], -500, -500)
+	want := sparseCells(s, 1000, 1000)
+
+	for range 4000 {
+		s.NextGen()
+	}
+	if got := sparseCells(s, 0, 0); !slices.Equal(got, want) {
+		t.Errorf("glider after 4000 generations = %v, want %v", got, want)
+	}
+	if got := s.CountLiveCells(); got != 5 {
+		t.Errorf("CountLiveCells() = %d, want 5", got)
+	}
+}
+
+// TestNewSparseGameRejectsB0 checks that a rule giving birth on 0 neighbors, which would
+// fill the infinite plane, is rejected.
+func TestNewSparseGameRejectsB0(t *testing.T) {
+	if _, err := NewSparseGame(mustParseRule("B0/S8"), Moore); err == nil {
+		t.Error("NewSparseGame(B0/S8) returned no error")
+	}
+}
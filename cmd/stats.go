@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+)
+
+// Density returns the fraction of the grid's cells that are alive.
+//
+// Returns:
+//   - The live-cell count divided by the total number of cells, from 0 to 1.
+func (g *Game) Density() float64 {
+	cells := g.grid.Width() * g.grid.Height()
+	if cells == 0 {
+		return 0
+	}
+	return float64(g.CountLiveCells()) / float64(cells)
+}
+
+// Entropy measures the local disorder of the grid as the Shannon entropy, in bits, of the
+// distribution of block configurations. The grid is partitioned into non-overlapping
+// blockSize x blockSize blocks starting at the top-left corner, and each distinct
+// arrangement of live cells within a block is counted; blocks cut off by the right or
+// bottom edge are ignored. A uniform grid scores 0, while a random grid approaches
+// blockSize*blockSize bits, limited by the number of blocks.
+//
+// Parameters:
+//   - blockSize: The side length of the blocks, at least 1.
+//
+// Returns:
+//   - The entropy in bits, or 0 if blockSize is below 1 or no whole block fits on the grid.
+func (g *Game) Entropy(blockSize int) float64 {
+	if blockSize < 1 {
+		return 0
+	}
+
+	counts := make(map[string]int)
+	blocks := 0
+	key := make([]byte, (blockSize*blockSize+7)/8)
+	for bx := 0; bx+blockSize <= g.grid.Height(); bx += blockSize {
+		for by := 0; by+blockSize <= g.grid.Width(); by += blockSize {
+			clear(key)
+			for i := range blockSize * blockSize {
+				if g.grid[bx+i/blockSize][by+i%blockSize] {
+					key[i/8] |= 1 << (i % 8)
+				}
+			}
+			counts[string(key)]++
+			blocks++
+		}
+	}
+
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(blocks)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Diff lists the cells that changed between an earlier state of the game and its current
+// state, in row-major order.
+//
+// Parameters:
+//   - prev: The earlier state, such as a Clone taken before NextGen.
+//
+// Returns:
+//   - born: The [row, column] cells alive now but dead in prev.
+//   - died: The [row, column] cells alive in prev but dead now.
+//
+// Diff panics if prev's grid does not have the same dimensions as the game's.
+func (g *Game) Diff(prev *Game) (born, died [][2]int) {
+	if prev.grid.Width() != g.grid.Width() || prev.grid.Height() != g.grid.Height() {
+		panic(fmt.Sprintf("Diff: grid is %dx%d but prev is %dx%d",
+			g.grid.Width(), g.grid.Height(), prev.grid.Width(), prev.grid.Height()))
+	}
+
+	for x, row := range g.grid {
+		for y, alive := range row {
+			switch was := prev.grid[x][y]; {
+			case alive && !was:
+				born = append(born, [2]int{x, y})
+			case was && !alive:
+				died = append(died, [2]int{x, y})
+			}
+		}
+	}
+	return born, died
+}
+
+// WritePopulationCSV writes the population history as CSV with the header
+// "generation,population,births,deaths" and one row per generation, starting with the
+// initial state, which has no births or deaths.
+//
+// Parameters:
+//   - w: The writer receiving the CSV data.
+//
+// Returns:
+//   - An error if writing to w fails.
+func (g *Game) WritePopulationCSV(w io.Writer) error {
+	history := g.PopulationHistory()
+	first := g.gen - (len(history) - 1)
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"generation", "population", "births", "deaths"})
+	for i, population := range history {
+		var change [2]int
+		if i > 0 {
+			change = g.changeHistory[i-1]
+		}
+		cw.Write([]string{
+			strconv.Itoa(first + i),
+			strconv.Itoa(population),
+			strconv.Itoa(change[0]),
+			strconv.Itoa(change[1]),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// savePopulationCSV writes the game's population history as CSV to the file at path.
+//
+// Parameters:
+//   - g: The game whose history is written.
+//   - path: The destination file path, created or truncated as needed.
+//
+// Returns:
+//   - An error if the file cannot be created or written.
+func savePopulationCSV(g *Game, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := g.WritePopulationCSV(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// CenterOfMass returns the average position of the live cells, in grid coordinates.
+//
+// On a toroidal axis the centroid of a pattern is ambiguous, since a pattern that wraps
+// across the edge can be unwrapped in several ways. CenterOfMass does not unwrap: it
+// averages the cells' positions on the grid, which lie within their bounding box, so
+// a pattern split across an edge yields a point between its two halves that may be a
+// dead cell far from either.
+//
+// Returns:
+//   - cx: The mean row of the live cells.
+//   - cy: The mean column of the live cells.
+//   - ok: false if there are no live cells.
+func (g *Game) CenterOfMass() (cx, cy float64, ok bool) {
+	n, sumX, sumY := 0, 0, 0
+	g.ForEachLive(func(x, y int) {
+		n++
+		sumX += x
+		sumY += y
+	})
+	if n == 0 {
+		return 0, 0, false
+	}
+	return float64(sumX) / float64(n), float64(sumY) / float64(n), true
+}
+
+// QuadrantCounts splits the grid at its center and counts the live cells in each
+// quadrant. The top half holds rows [0, height/2) and the left half columns [0, width/2),
+// so for an odd height or width the middle row or column counts toward the bottom or
+// right half.
+//
+// Returns:
+//   - The live-cell counts of the top-left, top-right, bottom-left and bottom-right
+//     quadrants, in that order.
+func (g *Game) QuadrantCounts() [4]int {
+	var counts [4]int
+	midX, midY := g.grid.Height()/2, g.grid.Width()/2
+	g.ForEachLive(func(x, y int) {
+		counts[2*btoi(x >= midX)+btoi(y >= midY)]++
+	})
+	return counts
+}
+
+// Drift returns how far the center of mass moved from an earlier state of the game to the
+// current one, approximating the pattern's velocity when prev is the previous
+// generation. On a toroidal axis a move of more than half the axis is taken as the
+// shorter move the other way around, so a pattern crossing the edge whole does not appear
+// to jump across the grid; while a pattern is split across the edge its center of mass,
+// and so its drift, is unreliable (see CenterOfMass).
+//
+// Parameters:
+//   - prev: The earlier state, such as a Clone taken before NextGen.
+//
+// Returns:
+//   - dx: The change of the mean row.
+//   - dy: The change of the mean column.
+//
+// Both are 0 if either state has no live cells.
+func (g *Game) Drift(prev *Game) (dx, dy float64) {
+	cx, cy, ok := g.CenterOfMass()
+	px, py, prevOK := prev.CenterOfMass()
+	if !ok || !prevOK {
+		return 0, 0
+	}
+	return wrapDrift(cx-px, g.grid.Height(), g.boundaryX), wrapDrift(cy-py, g.grid.Width(), g.boundaryY)
+}
+
+// wrapDrift maps a move along an axis of length n to the shortest equivalent one when the
+// axis wraps, like wrapOffset for fractional moves.
+func wrapDrift(d float64, n int, b Boundary) float64 {
+	if b != Toroidal {
+		return d
+	}
+	switch size := float64(n); {
+	case d > size/2:
+		return d - size
+	case d < -size/2:
+		return d + size
+	}
+	return d
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// TestDensity checks the live fraction of an empty, a half-filled and a full grid.
+func TestDensity(t *testing.T) {
+	tests := []struct {
+		name     string
+		fill     func(x, y int) bool
+		expected float64
+	}{
+		{"empty", func(x, y int) bool { return false }, 0},
+		{"left half", func(x, y int) bool { return y < 5 }, 0.5},
+		{"checkerboard", func(x, y int) bool { return (x+y)%2 == 0 }, 0.5},
+		{"full", func(x, y int) bool { return true }, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newEmptyGame(10, 6)
+			for x := range g.grid {
+				for y := range g.grid[x] {
+					g.grid[x][y] = tt.fill(x, y)
+				}
+			}
+			if got := g.Density(); got != tt.expected {
+				t.Errorf("Density() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEntropy checks that grids made of one repeated block score 0 bits, a random grid
+// scores close to the 4 bits of a 2x2 block, and block sizes that fit no block score 0.
+func TestEntropy(t *testing.T) {
+	checkerboard := newEmptyGame(64, 64)
+	for x := range checkerboard.grid {
+		for y := range checkerboard.grid[x] {
+			checkerboard.grid[x][y] = (x+y)%2 == 0
+		}
+	}
+	random := &Game{grid: randomGrid(64, 64, 0.5, 10)}
+
+	if got := newEmptyGame(64, 64).Entropy(2); got != 0 {
+		t.Errorf("Entropy(2) of an empty grid = %v, want 0", got)
+	}
+	if got := checkerboard.Entropy(2); got != 0 {
+		t.Errorf("Entropy(2) of a checkerboard = %v, want 0", got)
+	}
+	if got := random.Entropy(2); got < 3.9 || got > 4 {
+		t.Errorf("Entropy(2) of a random grid = %v, want between 3.9 and 4", got)
+	}
+	if ordered, disordered := checkerboard.Entropy(3), random.Entropy(3); ordered >= disordered {
+		t.Errorf("Entropy(3) of a checkerboard = %v, not below the random grid's %v", ordered, disordered)
+	}
+	for _, blockSize := range []int{0, 65} {
+		if got := random.Entropy(blockSize); got != 0 {
+			t.Errorf("Entropy(%d) = %v, want 0", blockSize, got)
+		}
+	}
+}
+
+// TestDiff steps a blinker and checks that each phase has exactly its two new end cells
+// born and its two old ones died, and that grids of different sizes panic.
+func TestDiff(t *testing.T) {
+	g := NewGame(WithSize(5, 5), WithPatternAt("blinker", 1, 1))
+	phases := [][2][][2]int{
+		{{{0, 2}, {2, 2}}, {{1, 1}, {1, 3}}},
+		{{{1, 1}, {1, 3}}, {{0, 2}, {2, 2}}},
+	}
+
+	for i := range 4 {
+		prev := g.Clone()
+		g.NextGen()
+		born, died := g.Diff(prev)
+		want := phases[i%2]
+		if !slices.Equal(born, want[0]) || !slices.Equal(died, want[1]) {
+			t.Errorf("generation %d: Diff() = %v, %v, want %v, %v", g.Generation(), born, died, want[0], want[1])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Diff() of grids of different sizes did not panic")
+		}
+	}()
+	g.Diff(newEmptyGame(5, 6))
+}
+
+// TestWritePopulationCSV steps a blinker, parses the CSV back and checks the header, the
+// row count and every row.
+func TestWritePopulationCSV(t *testing.T) {
+	g := NewGame(WithSize(5, 5), WithPatternAt("blinker", 1, 1))
+	g.Step(6)
+
+	var buf bytes.Buffer
+	if err := g.WritePopulationCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing the CSV: %v", err)
+	}
+	if len(records) != 1+7 {
+		t.Fatalf("CSV has %d rows, want a header and 7 generations", len(records))
+	}
+	if want := []string{"generation", "population", "births", "deaths"}; !slices.Equal(records[0], want) {
+		t.Errorf("header = %v, want %v", records[0], want)
+	}
+	for gen, record := range records[1:] {
+		want := []string{strconv.Itoa(gen), "3", "2", "2"}
+		if gen == 0 {
+			want = []string{"0", "3", "0", "0"}
+		}
+		if !slices.Equal(record, want) {
+			t.Errorf("row %d = %v, want %v", gen+1, record, want)
+		}
+	}
+}
+
+// TestCenterOfMass checks that symmetric patterns have their center of mass at their
+// geometric center and that an empty grid has none.
+func TestCenterOfMass(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		x, y    int
+		cx, cy  float64
+	}{
+		{"blinker", "blinker", 2, 1, 2, 2},
+		{"beacon", "beacon", 3, 3, 4.5, 4.5},
+		{"toad", "toad", 5, 0, 5.5, 1.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 10), WithPatternAt(tt.pattern, tt.x, tt.y))
+			cx, cy, ok := g.CenterOfMass()
+			if !ok || cx != tt.cx || cy != tt.cy {
+				t.Errorf("CenterOfMass() = %v, %v, %v, want %v, %v, true", cx, cy, ok, tt.cx, tt.cy)
+			}
+		})
+	}
+
+	if _, _, ok := NewGame(WithSize(10, 10), WithRandom(0, 1)).CenterOfMass(); ok {
+		t.Error("CenterOfMass() of an empty grid reports ok")
+	}
+}
+
+// TestQuadrantCounts places a glider entirely in each quadrant of a grid with odd
+// dimensions in turn, and checks that only that quadrant counts it and that the middle row
+// and column count toward the bottom and right halves.
+func TestQuadrantCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     int
+		expected [4]int
+	}{
+		{"top left", 0, 0, [4]int{5, 0, 0, 0}},
+		{"top right", 1, 6, [4]int{0, 5, 0, 0}},
+		{"bottom left", 6, 1, [4]int{0, 0, 5, 0}},
+		{"bottom right from the middle row and column", 4, 5, [4]int{0, 0, 0, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(11, 9), WithPatternAt("glider", tt.x, tt.y))
+			if got := g.QuadrantCounts(); got != tt.expected {
+				t.Errorf("QuadrantCounts() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDrift checks that a glider drifts one cell down and right over every period without
+// ever drifting up or left in between, and that an oscillator staying in place does not
+// drift.
+func TestDrift(t *testing.T) {
+	g := NewGame(WithSize(30, 30), WithPatternAt("glider", 1, 1))
+	for period := range 6 {
+		start := g.Clone()
+		for range 4 {
+			prev := g.Clone()
+			g.NextGen()
+			if dx, dy := g.Drift(prev); dx < 0 || dy < 0 {
+				t.Errorf("generation %d: Drift() = %v, %v, want no move up or left", g.Generation(), dx, dy)
+			}
+		}
+		if dx, dy := g.Drift(start); math.Abs(dx-1) > 1e-9 || math.Abs(dy-1) > 1e-9 {
+			t.Errorf("period %d: Drift() = %v, %v, want 1, 1", period, dx, dy)
+		}
+	}
+
+	blinker := NewGame(WithSize(12, 12), WithPatternAt("blinker", 5, 4))
+	prev := blinker.Clone()
+	blinker.NextGen()
+	if dx, dy := blinker.Drift(prev); dx != 0 || dy != 0 {
+		t.Errorf("Drift() of a blinker = %v, %v, want 0, 0", dx, dy)
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// RotatePattern rotates a set of cells a quarter turn clockwise.
+//
+// Parameters:
+//   - cells: The live cells as [row, column] offsets.
+//
+// Returns:
+//   - The rotated cells as offsets from the top-left corner of their bounding box.
+func RotatePattern(cells [][2]int) [][2]int {
+	if len(cells) == 0 {
+		return nil
+	}
+
+	minX, maxX := cells[0][0], cells[0][0]
+	minY := cells[0][1]
+	for _, c := range cells {
+		minX, maxX = min(minX, c[0]), max(maxX, c[0])
+		minY = min(minY, c[1])
+	}
+
+	// Clockwise, the bottom row becomes the left column and the left column the top row.
+	rotated := make([][2]int, len(cells))
+	for i, c := range cells {
+		rotated[i] = [2]int{c[1] - minY, maxX - c[0]}
+	}
+	return rotated
+}
+
+// Canonical returns a form of the live cells that is the same for every copy of the
+// pattern, wherever it lies on the grid and however it is rotated or reflected: of the 8
+// rotations and reflections of the cells, each taken relative to the top-left corner of
+// its bounding box and sorted in row-major order, the lexicographically smallest. Two
+// patterns are equal up to translation, rotation and reflection exactly when their
+// canonical forms are equal. A pattern that wraps across a toroidal edge is taken as it
+// lies on the grid, so it does not match an unwrapped copy.
+//
+// Returns:
+//   - The canonical live cells as [row, column] offsets, nil for an empty grid.
+func (g *Game) Canonical() [][2]int {
+	cells := g.LiveCells()
+	if cells == nil {
+		return nil
+	}
+
+	var best [][2]int
+	for range 4 {
+		cells = RotatePattern(cells)
+		for _, form := range [2][][2]int{cells, mirrorPattern(cells)} {
+			slices.SortFunc(form, comparePairs)
+			if best == nil || slices.CompareFunc(form, best, comparePairs) < 0 {
+				best = form
+			}
+		}
+	}
+	return best
+}
+
+// mirrorPattern reflects cells that start at column 0 left to right.
+func mirrorPattern(cells [][2]int) [][2]int {
+	maxY := 0
+	for _, c := range cells {
+		maxY = max(maxY, c[1])
+	}
+
+	mirrored := make([][2]int, len(cells))
+	for i, c := range cells {
+		mirrored[i] = [2]int{c[0], maxY - c[1]}
+	}
+	return mirrored
+}
+
+// comparePairs orders [row, column] pairs in row-major order.
+func comparePairs(a, b [2]int) int {
+	return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+}
+
+// Rotate90 rotates the live cells a quarter turn clockwise about the center of their
+// bounding box. If the rotated pattern would cross an edge it is shifted back onto the
+// grid, so a pattern is never clipped. Cell ages are reset.
+//
+// Returns:
+//   - An error if the rotated bounding box is larger than the grid, which can happen on
+//     a non-square grid; the game is left unchanged.
+func (g *Game) Rotate90() error {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		return nil
+	}
+
+	// The rotated box is as tall as the original is wide and vice versa.
+	height, width := maxY-minY+1, maxX-minX+1
+	if height > g.grid.Height() || width > g.grid.Width() {
+		return fmt.Errorf("rotated %dx%d pattern does not fit on the %dx%d grid",
+			width, height, g.grid.Width(), g.grid.Height())
+	}
+
+	ox := min(max(minX+(maxX-minX+1-height)/2, 0), g.grid.Height()-height)
+	oy := min(max(minY+(maxY-minY+1-width)/2, 0), g.grid.Width()-width)
+	g.replaceCells(RotatePattern(g.LiveCells()), ox, oy)
+
+	return nil
+}
+
+// replaceCells clears the grid and sets the given in-range cells live, offset by an
+// origin. Cell ages are reset.
+//
+// Parameters:
+//   - cells: The live cells as [row, column] offsets from the origin.
+//   - ox: The row of the origin.
+//   - oy: The column of the origin.
+func (g *Game) replaceCells(cells [][2]int, ox, oy int) {
+	g.Clear()
+	for _, c := range cells {
+		g.grid[ox+c[0]][oy+c[1]] = true
+	}
+}
+
+// FlipH mirrors the grid left to right across its vertical center axis, so the cell in
+// column y moves to column width-1-y. Cell ages and heat map counts move with their cells.
+func (g *Game) FlipH() {
+	for x := range g.grid {
+		slices.Reverse(g.grid[x])
+	}
+	for x := range g.ages {
+		slices.Reverse(g.ages[x])
+	}
+	for x := range g.heat {
+		slices.Reverse(g.heat[x])
+	}
+	for x := range g.diedAt {
+		slices.Reverse(g.diedAt[x])
+	}
+	g.active = nil
+}
+
+// FlipV mirrors the grid top to bottom across its horizontal center axis, so the cell in
+// row x moves to row height-1-x. Cell ages and heat map counts move with their cells.
+func (g *Game) FlipV() {
+	slices.Reverse(g.grid)
+	slices.Reverse(g.ages)
+	slices.Reverse(g.heat)
+	slices.Reverse(g.diedAt)
+	g.active = nil
+}
+
+// Crop shrinks the grid to the bounding box of its live cells, keeping their relative
+// positions and ages. An empty grid is cropped to a single dead cell.
+func (g *Game) Crop() {
+	minX, minY, maxX, maxY, ok := g.BoundingBox()
+	if !ok {
+		g.reframe(1, 1, 0, 0)
+		return
+	}
+	g.reframe(maxY-minY+1, maxX-minX+1, minX, minY)
+}
+
+// reframe replaces the grid with a width x height grid whose cell (x, y) is copied from
+// cell (x+ox, y+oy) of the old grid, or dead where that lies outside it. Ages and heat
+// map counts are copied the same way.
+//
+// Parameters:
+//   - width: The number of columns of the new grid.
+//   - height: The number of rows of the new grid.
+//   - ox: The row of the old grid that becomes row 0.
+//   - oy: The column of the old grid that becomes column 0.
+func (g *Game) reframe(width, height, ox, oy int) {
+	grid := newGrid(width, height)
+	ages, heat := resizeCounts(g.ages, width, height), resizeCounts(g.heat, width, height)
+
+	for x := max(0, -ox); x < height && x+ox < g.grid.Height(); x++ {
+		for y := max(0, -oy); y < width && y+oy < g.grid.Width(); y++ {
+			grid[x][y] = g.grid[x+ox][y+oy]
+			if ages != nil {
+				ages[x][y] = g.ages[x+ox][y+oy]
+			}
+			if heat != nil {
+				heat[x][y] = g.heat[x+ox][y+oy]
+			}
+		}
+	}
+
+	g.grid, g.ages, g.heat, g.diedAt, g.active = grid, ages, heat, nil, nil
+}
+
+// resizeCounts allocates a zeroed width x height replacement for a per-cell counter such
+// as ages, or returns nil if the counter is not allocated.
+func resizeCounts(counts [][]int, width, height int) [][]int {
+	if counts == nil {
+		return nil
+	}
+	resized := make([][]int, height)
+	for x := range resized {
+		resized[x] = make([]int, width)
+	}
+	return resized
+}
+
+// Resize reallocates the grid with new dimensions, anchored at the top-left corner: every
+// cell keeps its coordinates and age, cells beyond a shrunk edge are dropped and new rows
+// and columns start dead.
+//
+// Parameters:
+//   - width: The new number of columns, at least 1.
+//   - height: The new number of rows, at least 1.
+//
+// Returns:
+//   - An error if either dimension is below 1; the game is left unchanged.
+func (g *Game) Resize(width, height int) error {
+	if width < 1 || height < 1 {
+		return fmt.Errorf("invalid grid size %dx%d", width, height)
+	}
+	g.reframe(width, height, 0, 0)
+	return nil
+}
+
+// Union makes every cell alive that is alive in the game or in other, adding other's
+// live cells to the grid.
+//
+// Parameters:
+//   - other: A game of the same dimensions.
+//
+// Returns:
+//   - An error if the dimensions differ; the game is left unchanged.
+func (g *Game) Union(other *Game) error {
+	return g.combine(other, func(a, b bool) bool { return a || b })
+}
+
+// Intersect keeps only the cells that are alive both in the game and in other.
+//
+// Parameters:
+//   - other: A game of the same dimensions.
+//
+// Returns:
+//   - An error if the dimensions differ; the game is left unchanged.
+func (g *Game) Intersect(other *Game) error {
+	return g.combine(other, func(a, b bool) bool { return a && b })
+}
+
+// Subtract kills every cell that is alive in other, keeping the game's other live cells.
+//
+// Parameters:
+//   - other: A game of the same dimensions.
+//
+// Returns:
+//   - An error if the dimensions differ; the game is left unchanged.
+func (g *Game) Subtract(other *Game) error {
+	return g.combine(other, func(a, b bool) bool { return a && !b })
+}
+
+// combine sets every cell to op applied to its state and the state of the same cell in
+// other. Cells whose state does not change keep their ages.
+func (g *Game) combine(other *Game, op func(a, b bool) bool) error {
+	if other.grid.Width() != g.grid.Width() || other.grid.Height() != g.grid.Height() {
+		return fmt.Errorf("grid is %dx%d but other is %dx%d",
+			g.grid.Width(), g.grid.Height(), other.grid.Width(), other.grid.Height())
+	}
+
+	for x, row := range g.grid {
+		for y, alive := range row {
+			if next := op(alive, other.grid[x][y]); next != alive {
+				g.Set(x, y, next)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestFlipMovesHeat runs a glider with the heat map enabled, flips the grid both ways and
+// checks that every heat map count moved with its cell.
+func TestFlipMovesHeat(t *testing.T) {
+	tests := []struct {
+		name  string
+		flip  func(*Game)
+		moved func(x, y, width, height int) (int, int)
+	}{
+		{"FlipH", (*Game).FlipH, func(x, y, width, _ int) (int, int) { return x, width - 1 - y }},
+		{"FlipV", (*Game).FlipV, func(x, y, _, height int) (int, int) { return height - 1 - x, y }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(20, 12), WithPatternAt("glider", 1, 2), WithHeatmap())
+			g.Step(6)
+			before := g.Clone()
+			width, height := g.grid.Width(), g.grid.Height()
+
+			tt.flip(g)
+			for x := range height {
+				for y := range width {
+					fx, fy := tt.moved(x, y, width, height)
+					if g.heat[fx][fy] != before.heat[x][y] || g.grid[fx][fy] != before.grid[x][y] {
+						t.Fatalf("cell (%d, %d) with heat %d moved to (%d, %d) with heat %d",
+							x, y, before.heat[x][y], fx, fy, g.heat[fx][fy])
+					}
+				}
+			}
+		})
+	}
+}
+
+// lShape is an L-shaped test pattern: a vertical bar of three cells with a foot pointing
+// right from its bottom cell.
+var lShape = [][2]int{{0, 0}, {1, 0}, {2, 0}, {2, 1}}
+
+// TestRotatePattern checks one clockwise quarter turn of an L-shape and that four turns
+// bring it back.
+func TestRotatePattern(t *testing.T) {
+	want := [][2]int{{0, 2}, {0, 1}, {0, 0}, {1, 0}}
+	if got := RotatePattern(lShape); !slices.Equal(got, want) {
+		t.Errorf("RotatePattern(L) = %v, want %v", got, want)
+	}
+
+	cells := lShape
+	for range 4 {
+		cells = RotatePattern(cells)
+	}
+	if !slices.Equal(cells, lShape) {
+		t.Errorf("four quarter turns of L = %v, want %v", cells, lShape)
+	}
+}
+
+// TestRotate90 rotates an L-shape on the grid, in the open and against an edge it would
+// otherwise cross, and checks that a rotation too tall for the grid is refused.
+func TestRotate90(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		cells    [][2]int
+		ox, oy   int
+		expected [][2]int
+		wantErr  bool
+	}{
+		{"in the open", 10, 10, lShape, 3, 4, [][2]int{{3, 4}, {3, 5}, {3, 6}, {4, 4}}, false},
+		{"shifted off the right edge", 10, 10, lShape, 7, 8, [][2]int{{7, 7}, {7, 8}, {7, 9}, {8, 7}}, false},
+		{"too tall", 10, 3, [][2]int{{0, 0}, {0, 1}, {0, 2}, {0, 3}}, 1, 2, [][2]int{{1, 2}, {1, 3}, {1, 4}, {1, 5}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(tt.width, tt.height), WithBoundary(Dead), WithRandom(0, 1))
+			if err := g.Place(tt.cells, tt.ox, tt.oy); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Rotate90(); (err != nil) != tt.wantErr {
+				t.Fatalf("Rotate90() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := g.LiveCells(); !slices.Equal(got, tt.expected) {
+				t.Errorf("live cells after Rotate90() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCrop checks that a pattern surrounded by wide dead borders is cropped to its
+// bounding box with its shape and ages intact, and that an empty grid becomes one dead cell.
+func TestCrop(t *testing.T) {
+	g := NewGame(WithSize(40, 30), WithRandom(0, 1))
+	if err := g.Place(lShape, 12, 20); err != nil {
+		t.Fatal(err)
+	}
+	g.Step(2) // gives the surviving cells ages to keep
+	want, ages := boxCells(g), g.Clone()
+	minX, minY, maxX, maxY, _ := g.BoundingBox()
+
+	g.Crop()
+	if g.grid.Width() != maxY-minY+1 || g.grid.Height() != maxX-minX+1 {
+		t.Fatalf("cropped grid is %dx%d, want %dx%d", g.grid.Width(), g.grid.Height(), maxY-minY+1, maxX-minX+1)
+	}
+	if got := g.LiveCells(); !slices.Equal(got, want) {
+		t.Errorf("live cells after Crop() = %v, want %v", got, want)
+	}
+	g.ForEachLive(func(x, y int) {
+		if g.Age(x, y) != ages.Age(x+minX, y+minY) {
+			t.Errorf("Age(%d, %d) = %d after Crop(), want %d", x, y, g.Age(x, y), ages.Age(x+minX, y+minY))
+		}
+	})
+
+	empty := NewGame(WithSize(40, 30), WithRandom(0, 1))
+	empty.Crop()
+	if empty.grid.Width() != 1 || empty.grid.Height() != 1 || empty.CountLiveCells() != 0 {
+		t.Errorf("empty grid cropped to %dx%d with %d live cells, want 1x1 with 0",
+			empty.grid.Width(), empty.grid.Height(), empty.CountLiveCells())
+	}
+}
+
+// TestResize grows and shrinks a grid and checks that the cells keep their coordinates,
+// cells beyond a shrunk edge are dropped and invalid sizes are refused.
+func TestResize(t *testing.T) {
+	cells := [][2]int{{0, 0}, {2, 5}, {4, 1}, {4, 9}}
+	tests := []struct {
+		name          string
+		width, height int
+		expected      [][2]int
+		wantErr       bool
+	}{
+		{"grow", 20, 15, cells, false},
+		{"shrink", 6, 4, [][2]int{{0, 0}, {2, 5}}, false},
+		{"zero width", 0, 5, cells, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(10, 5), WithRandom(0, 1))
+			g.SetLiveCells(cells)
+
+			err := g.Resize(tt.width, tt.height)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resize(%d, %d) error = %v, want error %v", tt.width, tt.height, err, tt.wantErr)
+			}
+			if width, height := g.grid.Width(), g.grid.Height(); !tt.wantErr && (width != tt.width || height != tt.height) {
+				t.Errorf("grid is %dx%d after Resize, want %dx%d", width, height, tt.width, tt.height)
+			}
+			if got := g.LiveCells(); !slices.Equal(got, tt.expected) {
+				t.Errorf("live cells after Resize(%d, %d) = %v, want %v", tt.width, tt.height, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCombine applies each set operation to a 3x3 square and a 3x3 plus sign overlapping
+// it in three cells, and checks that grids of different sizes are refused.
+func TestCombine(t *testing.T) {
+	square := [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {2, 3}, {3, 1}, {3, 2}, {3, 3}}
+	plus := [][2]int{{2, 3}, {3, 2}, {3, 3}, {3, 4}, {4, 3}}
+	tests := []struct {
+		name     string
+		op       func(g, other *Game) error
+		expected [][2]int
+	}{
+		{"Union", (*Game).Union, [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {2, 3}, {3, 1}, {3, 2}, {3, 3}, {3, 4}, {4, 3}}},
+		{"Intersect", (*Game).Intersect, [][2]int{{2, 3}, {3, 2}, {3, 3}}},
+		{"Subtract", (*Game).Subtract, [][2]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {3, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, other := NewGame(WithSize(6, 6), WithRandom(0, 1)), NewGame(WithSize(6, 6), WithRandom(0, 1))
+			g.SetLiveCells(square)
+			other.SetLiveCells(plus)
+
+			if err := tt.op(g, other); err != nil {
+				t.Fatal(err)
+			}
+			if got := g.LiveCells(); !slices.Equal(got, tt.expected) {
+				t.Errorf("%s() = %v, want %v", tt.name, got, tt.expected)
+			}
+			if !slices.Equal(other.LiveCells(), plus) {
+				t.Errorf("%s() changed its argument", tt.name)
+			}
+
+			if err := tt.op(g, NewGame(WithSize(6, 7))); err == nil {
+				t.Errorf("%s() with a 6x7 grid returned no error", tt.name)
+			}
+			if got := g.LiveCells(); !slices.Equal(got, tt.expected) {
+				t.Errorf("%s() with a 6x7 grid changed the cells to %v", tt.name, got)
+			}
+		})
+	}
+}
+
+// TestCanonical turns and mirrors patterns into all eight of their orientations, at
+// different positions, and checks that every orientation of a pattern has the same
+// canonical form, and so the same shape hash once canonicalized, while a glider and an
+// r-pentomino differ.
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		distinct int // the number of different shapes among the eight orientations
+	}{
+		{"glider", 8},
+		{"rpentomino", 8},
+		{"beacon", 2},
+	}
+
+	canonical := make(map[string][][2]int)
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			g := NewGame(WithSize(20, 20), WithBoundary(Dead), WithPatternAt(tt.pattern, 8, 8))
+			want := g.Canonical()
+			wantHash := canonicalShapeHash(want)
+			shapes := make(map[uint64]bool)
+
+			for i := range 8 {
+				if i == 4 {
+					g.FlipH()
+				}
+				if err := g.Rotate90(); err != nil {
+					t.Fatal(err)
+				}
+				shapes[g.ShapeHash()] = true
+
+				if got := g.Canonical(); !slices.Equal(got, want) {
+					t.Errorf("orientation %d: Canonical() = %v, want %v", i, got, want)
+				}
+				if got := canonicalShapeHash(g.Canonical()); got != wantHash {
+					t.Errorf("orientation %d: canonical shape hash = %#x, want %#x", i, got, wantHash)
+				}
+			}
+			if len(shapes) != tt.distinct {
+				t.Errorf("the eight orientations have %d different shapes, want %d", len(shapes), tt.distinct)
+			}
+			canonical[tt.pattern] = want
+		})
+	}
+
+	if slices.Equal(canonical["glider"], canonical["rpentomino"]) {
+		t.Error("a glider and an r-pentomino have the same canonical form")
+	}
+}
+
+// canonicalShapeHash returns the shape hash of a grid holding the given canonical cells.
+func canonicalShapeHash(cells [][2]int) uint64 {
+	g := NewGame(WithSize(20, 20), WithRandom(0, 1))
+	g.SetLiveCells(cells)
+	return g.ShapeHash()
+}
@@ -0,0 +1,112 @@
+package main
+
+import "slices"
+
+// snapshot is the state of a game before a NextGen, restored by Undo. The grid is
+// bit-packed, so a long history takes an eighth of the memory of full grid copies.
+type snapshot struct {
+	grid       *BitGrid
+	ages       [][]int
+	lastBirths int
+	lastDeaths int
+}
+
+// undoBuffer is a ring buffer of the most recent snapshots. Snapshots are never modified
+// once pushed, so copies of the buffer may share them.
+type undoBuffer struct {
+	snapshots []snapshot // oldest first
+	limit     int
+}
+
+// push records a snapshot, forgetting the oldest one when the buffer is full.
+func (u *undoBuffer) push(s snapshot) {
+	if len(u.snapshots) == u.limit {
+		u.snapshots = slices.Delete(u.snapshots, 0, 1)
+	}
+	u.snapshots = append(u.snapshots, s)
+}
+
+// pop removes and returns the most recent snapshot.
+//
+// Returns:
+//   - The snapshot.
+//   - false if the buffer is empty.
+func (u *undoBuffer) pop() (snapshot, bool) {
+	if len(u.snapshots) == 0 {
+		return snapshot{}, false
+	}
+	s := u.snapshots[len(u.snapshots)-1]
+	u.snapshots = u.snapshots[:len(u.snapshots)-1]
+	return s, true
+}
+
+// EnableUndo makes every following NextGen remember the state it replaces, so that up to
+// limit generations can be stepped back with Undo. A limit below 1 disables undo and
+// discards the remembered states.
+//
+// Parameters:
+//   - limit: The maximum number of generations remembered.
+func (g *Game) EnableUndo(limit int) {
+	if limit < 1 {
+		g.undo = nil
+		return
+	}
+	g.undo = &undoBuffer{limit: limit}
+}
+
+// Undo restores the state from before the most recent NextGen: the grid, cell ages and
+// birth/death counts, and decrements the generation. Heat map counts are not rewound. A
+// grid resized or cropped since that NextGen gets its earlier dimensions back, and the
+// heat map is reframed with it as by Resize.
+//
+// Returns:
+//   - true if a state was restored, false if undo is disabled or no earlier state is
+//     remembered.
+func (g *Game) Undo() bool {
+	if g.undo == nil {
+		return false
+	}
+	s, ok := g.undo.pop()
+	if !ok {
+		return false
+	}
+
+	if s.grid.Width() != g.grid.Width() || s.grid.Height() != g.grid.Height() {
+		g.reframe(s.grid.Width(), s.grid.Height(), 0, 0)
+	}
+	g.grid, g.ages = s.grid.Grid(), s.ages
+	g.lastBirths, g.lastDeaths = s.lastBirths, s.lastDeaths
+	g.gen--
+	if len(g.populationHistory) > 1 {
+		g.populationHistory = g.populationHistory[:len(g.populationHistory)-1]
+		g.changeHistory = g.changeHistory[:len(g.changeHistory)-1]
+
+		// The peak may have been the generation just undone.
+		first := g.gen - (len(g.populationHistory) - 1)
+		g.maxPopulation, g.maxPopulationGen = g.populationHistory[0], first
+		for i, population := range g.populationHistory {
+			if population > g.maxPopulation {
+				g.maxPopulation, g.maxPopulationGen = population, first+i
+			}
+		}
+	}
+	g.diedAt, g.active = nil, nil
+
+	return true
+}
+
+// remember pushes the current state onto the undo buffer, if undo is enabled.
+func (g *Game) remember() {
+	if g.undo == nil {
+		return
+	}
+
+	s := snapshot{grid: BitGridFrom(g.grid), lastBirths: g.lastBirths, lastDeaths: g.lastDeaths}
+	if g.ages != nil {
+		s.ages = make([][]int, len(g.ages))
+		for x := range g.ages {
+			s.ages[x] = slices.Clone(g.ages[x])
+		}
+	}
+	g.undo.push(s)
+}
@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+// TestUndoSteps steps a random game forward three times and checks that undoing each step
+// brings back the generation before it, down to the starting grid.
+func TestUndoSteps(t *testing.T) {
+	g := NewGame(WithSize(30, 20), WithRandom(0.35, 5))
+	g.EnableUndo(10)
+
+	var earlier []*Game
+	for range 3 {
+		earlier = append(earlier, g.Clone())
+		g.NextGen()
+	}
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if !g.Undo() {
+			t.Fatalf("Undo() = false at generation %d", g.Generation())
+		}
+		if g.Generation() != i || !g.Equal(earlier[i]) {
+			t.Fatalf("Undo() restored generation %d, want the grid of generation %d", g.Generation(), i)
+		}
+	}
+	if g.Undo() {
+		t.Error("Undo() = true with no earlier state remembered")
+	}
+}
+
+// TestUndoAfterResize checks that undoing a generation from before a Resize restores the
+// earlier dimensions together with the heat map, so the next NextGen does not index past
+// its end.
+func TestUndoAfterResize(t *testing.T) {
+	g := NewGame(WithHeatmap())
+	g.EnableUndo(10)
+	before := g.Clone()
+	g.NextGen()
+
+	if err := g.Resize(10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Undo() {
+		t.Fatal("Undo() = false after a NextGen")
+	}
+	if !g.Equal(before) {
+		t.Fatal("Undo() after Resize did not restore the earlier grid")
+	}
+	if len(g.heat) != g.grid.Height() || len(g.heat[0]) != g.grid.Width() {
+		t.Fatalf("heat map is %dx%d on a %dx%d grid", len(g.heat[0]), len(g.heat),
+			g.grid.Width(), g.grid.Height())
+	}
+	g.NextGen()
+}